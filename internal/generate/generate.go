@@ -0,0 +1,335 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package generate implements the generate command, which writes the
+// Terraform configuration adopting the applications of a Gotify instance.
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Application is an application of the Gotify instance.
+type Application struct {
+	ID              int64  `json:"id"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	DefaultPriority int64  `json:"defaultPriority"`
+	Internal        bool   `json:"internal"`
+}
+
+// Run runs the generate command with args, the arguments following
+// "generate", and returns its exit code.
+func Run(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: terraform-provider-gotify generate [options]")
+		fmt.Fprintln(stderr, "\nWrites gotify_application resources and import blocks for every application of a Gotify instance.")
+		fmt.Fprintln(stderr, "With -state, applications already managed are moved to their new address instead, and the resources")
+		fmt.Fprintln(stderr, "managing them twice or managing deleted applications are removed from the state.")
+		fmt.Fprintln(stderr, "\nOptions:")
+		flags.PrintDefaults()
+	}
+
+	url := flags.String("url", os.Getenv("GOTIFY_URL"), "URL of the Gotify instance, defaults to GOTIFY_URL")
+	token := flags.String("token", os.Getenv("GOTIFY_TOKEN"), "client token, defaults to GOTIFY_TOKEN")
+	output := flags.String("output", "-", "file the configuration is written to, - for the standard output")
+	state := flags.String("state", "", "file holding the output of terraform show -json for the configuration the applications are consolidated into")
+	stateModule := flags.String("state-module", "", "only consider the resources of -state declared in this module, e.g. module.alerts, when the state manages several instances")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *url == "" || *token == "" {
+		fmt.Fprintln(stderr, "Error: -url and -token must be set, or GOTIFY_URL and GOTIFY_TOKEN")
+		return 2
+	}
+
+	applications, err := ListApplications(context.Background(), http.DefaultClient, *url, *token)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	var managed []ManagedApplication
+	if *state != "" {
+		file, err := os.Open(*state)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %s\n", err)
+			return 1
+		}
+		managed, err = ReadState(file, *stateModule)
+		file.Close()
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %s: %s\n", *state, err)
+			return 1
+		}
+	}
+
+	out := stdout
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %s\n", err)
+			return 1
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if err := Write(out, applications, managed); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// ListApplications returns the applications of the Gotify instance at url.
+func ListApplications(ctx context.Context, client *http.Client, url string, token string) ([]Application, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", strings.TrimRight(url, "/")+"/application", nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("X-Gotify-Key", token)
+
+	httpRes, err := client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpRes.Body.Close()
+
+	if httpRes.StatusCode != 200 {
+		return nil, fmt.Errorf("GET /application returned %s", httpRes.Status)
+	}
+
+	var applications []Application
+	if err := json.NewDecoder(httpRes.Body).Decode(&applications); err != nil {
+		return nil, err
+	}
+
+	return applications, nil
+}
+
+// Write writes a gotify_application resource for every application,
+// preceded by a migration plan. Internal applications, created by plugins,
+// are left out.
+//
+// Applications are adopted by import blocks, unless managed already lists
+// them: the resource managing an application is then moved to its new
+// address, and the resources managing it a second time, or managing an
+// application which doesn't exist anymore, are removed from the state
+// without destroying anything.
+func Write(w io.Writer, applications []Application, managed []ManagedApplication) error {
+	applications = append([]Application{}, applications...)
+	sort.Slice(applications, func(i, j int) bool { return applications[i].ID < applications[j].ID })
+
+	managed = append([]ManagedApplication{}, managed...)
+	sort.Slice(managed, func(i, j int) bool { return managed[i].Address < managed[j].Address })
+
+	addresses := map[int64][]ManagedApplication{}
+	for _, resource := range managed {
+		addresses[resource.ID] = append(addresses[resource.ID], resource)
+	}
+
+	var blocks strings.Builder
+	var plan migrationPlan
+	names := map[string]bool{}
+	adopted := map[string][]string{}
+
+	for _, application := range applications {
+		if application.Internal {
+			continue
+		}
+
+		name := uniqueName(resourceName(application.Name), names)
+		address := "gotify_application." + name
+
+		adopted[resourceName(application.Name)] = append(adopted[resourceName(application.Name)], describeAdoption(application, addresses[application.ID], address))
+
+		switch resources := addresses[application.ID]; {
+		case len(resources) == 0:
+			plan.imported++
+			fmt.Fprintf(&blocks, "import {\n  to = %s\n  id = \"%d\"\n}\n\n", address, application.ID)
+		case resources[0].Address != address:
+			plan.moved++
+			fmt.Fprintf(&blocks, "moved {\n  from = %s\n  to   = %s\n}\n\n", resources[0].Address, address)
+			fallthrough
+		default:
+			for _, resource := range resources[1:] {
+				plan.removed = append(plan.removed, fmt.Sprintf("%s also manages application %d", resource.Address, application.ID))
+				writeRemoved(&blocks, resource.Address)
+			}
+		}
+		delete(addresses, application.ID)
+
+		fmt.Fprintf(&blocks, `resource "gotify_application" "%s" {
+  name             = %s
+  description      = %s
+  default_priority = "%d"
+}
+
+`, name, hclString(application.Name), hclString(application.Description), application.DefaultPriority)
+	}
+
+	for _, resource := range managed {
+		if _, ok := addresses[resource.ID]; ok {
+			plan.removed = append(plan.removed, fmt.Sprintf("%s manages application %d, which doesn't exist anymore or is internal", resource.Address, resource.ID))
+			writeRemoved(&blocks, resource.Address)
+		}
+	}
+
+	for _, base := range sortedKeys(adopted) {
+		if len(adopted[base]) > 1 {
+			plan.collisions = append(plan.collisions, strings.Join(adopted[base], ", "))
+		}
+	}
+
+	if _, err := io.WriteString(w, plan.String()); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, blocks.String())
+	return err
+}
+
+// writeRemoved writes the removed block forgetting the resource at address
+// without destroying its application.
+func writeRemoved(w io.Writer, address string) {
+	fmt.Fprintf(w, "removed {\n  from = %s\n\n  lifecycle {\n    destroy = false\n  }\n}\n\n", address)
+}
+
+// describeAdoption describes application, managed by resources, adopted at
+// address, for the name collisions of the migration plan.
+func describeAdoption(application Application, resources []ManagedApplication, address string) string {
+	var modules []string
+	for _, resource := range resources {
+		if module := resource.Module(); module != "" && !contains(modules, module) {
+			modules = append(modules, module)
+		}
+	}
+
+	if len(modules) == 0 {
+		return fmt.Sprintf("%q (id %d) as %s", application.Name, application.ID, address)
+	}
+
+	return fmt.Sprintf("%q (id %d, from %s) as %s", application.Name, application.ID, strings.Join(modules, " and "), address)
+}
+
+// migrationPlan summarizes the blocks written by Write.
+type migrationPlan struct {
+	imported int
+	moved    int
+	// removed explains each removed block.
+	removed []string
+	// collisions describes each group of applications whose names make the
+	// same resource name, suffixed to tell them apart.
+	collisions []string
+}
+
+// String returns the plan as HCL comments.
+func (p migrationPlan) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Migration plan: %d imported, %d moved, %d removed from the state.\n", p.imported, p.moved, len(p.removed))
+	for _, removed := range p.removed {
+		fmt.Fprintf(&b, "# Removed: %s.\n", removed)
+	}
+	for _, collision := range p.collisions {
+		fmt.Fprintf(&b, "# Name collision: %s.\n", collision)
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// contains reports whether values contains value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortedKeys returns the keys of m in increasing order.
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// invalidNameCharacters matches the characters not allowed in the name of a
+// resource.
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// resourceName turns the name of an application into a resource name.
+func resourceName(name string) string {
+	resourceName := strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(name), "_"), "_-")
+
+	if resourceName == "" || (resourceName[0] >= '0' && resourceName[0] <= '9') {
+		resourceName = "application_" + resourceName
+	}
+
+	return strings.TrimRight(resourceName, "_")
+}
+
+// uniqueName returns name, suffixed by a number when it is already in
+// names, and adds the result to names.
+func uniqueName(name string, names map[string]bool) string {
+	unique := name
+	for i := 2; names[unique]; i++ {
+		unique = name + "_" + strconv.Itoa(i)
+	}
+	names[unique] = true
+
+	return unique
+}
+
+// hclString returns value as a quoted HCL string, escaping template
+// sequences so it is written literally.
+func hclString(value string) string {
+	var b strings.Builder
+
+	b.WriteByte('"')
+	for _, r := range value {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+
+	escaped := strings.ReplaceAll(b.String(), "${", "$${")
+	return strings.ReplaceAll(escaped, "%{", "%%{")
+}
@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package generate
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestListApplications(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Gotify-Key") != "CToken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":1,"name":"backups","description":"Nightly","defaultPriority":5,"internal":false}]`))
+	}))
+	defer server.Close()
+
+	applications, err := ListApplications(context.Background(), server.Client(), server.URL+"/", "CToken")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(applications) != 1 || applications[0].Name != "backups" || applications[0].DefaultPriority != 5 {
+		t.Fatalf("unexpected applications %+v", applications)
+	}
+
+	if _, err := ListApplications(context.Background(), server.Client(), server.URL, "wrong"); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var out bytes.Buffer
+
+	err := Write(&out, []Application{
+		{ID: 4, Name: "Backups", Description: "Copy of \"${var}\"\nnightly", DefaultPriority: 2},
+		{ID: 2, Name: "backups", DefaultPriority: 5},
+		{ID: 3, Name: "plugin", Internal: true},
+		{ID: 7, Name: "2fa codes"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# Migration plan: 3 imported, 0 moved, 0 removed from the state.
+# Name collision: "backups" (id 2) as gotify_application.backups, "Backups" (id 4) as gotify_application.backups_2.
+
+import {
+  to = gotify_application.backups
+  id = "2"
+}
+
+resource "gotify_application" "backups" {
+  name             = "backups"
+  description      = ""
+  default_priority = "5"
+}
+
+import {
+  to = gotify_application.backups_2
+  id = "4"
+}
+
+resource "gotify_application" "backups_2" {
+  name             = "Backups"
+  description      = "Copy of \"$${var}\"\nnightly"
+  default_priority = "2"
+}
+
+import {
+  to = gotify_application.application_2fa_codes
+  id = "7"
+}
+
+resource "gotify_application" "application_2fa_codes" {
+  name             = "2fa codes"
+  description      = ""
+  default_priority = "0"
+}
+
+`
+	if out.String() != expected {
+		t.Fatalf("unexpected configuration:\n%s", out.String())
+	}
+}
+
+func TestWriteMigration(t *testing.T) {
+	var out bytes.Buffer
+
+	err := Write(&out, []Application{
+		{ID: 2, Name: "backups", DefaultPriority: 5},
+		{ID: 3, Name: "deploys", DefaultPriority: 1},
+		{ID: 4, Name: "Backups", DefaultPriority: 2},
+		{ID: 5, Name: "alerts", DefaultPriority: 8},
+	}, []ManagedApplication{
+		{Address: "module.team_a.gotify_application.backups", ID: 2},
+		{Address: "gotify_application.deploys", ID: 3},
+		{Address: "module.team_b.gotify_application.backups", ID: 4},
+		{Address: "module.team_b.gotify_application.nightly", ID: 4},
+		{Address: "module.team_b.gotify_application.deleted", ID: 9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# Migration plan: 1 imported, 2 moved, 2 removed from the state.
+# Removed: module.team_b.gotify_application.nightly also manages application 4.
+# Removed: module.team_b.gotify_application.deleted manages application 9, which doesn't exist anymore or is internal.
+# Name collision: "backups" (id 2, from module.team_a) as gotify_application.backups, "Backups" (id 4, from module.team_b) as gotify_application.backups_2.
+
+moved {
+  from = module.team_a.gotify_application.backups
+  to   = gotify_application.backups
+}
+
+resource "gotify_application" "backups" {
+  name             = "backups"
+  description      = ""
+  default_priority = "5"
+}
+
+resource "gotify_application" "deploys" {
+  name             = "deploys"
+  description      = ""
+  default_priority = "1"
+}
+
+moved {
+  from = module.team_b.gotify_application.backups
+  to   = gotify_application.backups_2
+}
+
+removed {
+  from = module.team_b.gotify_application.nightly
+
+  lifecycle {
+    destroy = false
+  }
+}
+
+resource "gotify_application" "backups_2" {
+  name             = "Backups"
+  description      = ""
+  default_priority = "2"
+}
+
+import {
+  to = gotify_application.alerts
+  id = "5"
+}
+
+resource "gotify_application" "alerts" {
+  name             = "alerts"
+  description      = ""
+  default_priority = "8"
+}
+
+removed {
+  from = module.team_b.gotify_application.deleted
+
+  lifecycle {
+    destroy = false
+  }
+}
+
+`
+	if out.String() != expected {
+		t.Fatalf("unexpected configuration:\n%s", out.String())
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	tests := map[string]string{
+		"backups":        "backups",
+		"Home Assistant": "home_assistant",
+		"CI / deploys!":  "ci_deploys",
+		"2fa":            "application_2fa",
+		"***":            "application",
+	}
+
+	for name, expected := range tests {
+		if got := resourceName(name); got != expected {
+			t.Errorf("expected %q for %q, got %q", expected, name, got)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ManagedApplication is a gotify_application resource of an existing
+// Terraform state.
+type ManagedApplication struct {
+	// Address is the address of the resource, e.g.
+	// module.alerts.gotify_application.backups.
+	Address string
+	// ID is the id of the application the resource manages.
+	ID int64
+}
+
+// Module returns the address of the module declaring the resource, or an
+// empty string for the root module.
+func (m ManagedApplication) Module() string {
+	index := strings.LastIndex(m.Address, "gotify_application.")
+	if index <= 0 {
+		return ""
+	}
+
+	return strings.TrimSuffix(m.Address[:index], ".")
+}
+
+// stateModule is a module of the output of terraform show -json.
+type stateModule struct {
+	Address   string `json:"address"`
+	Resources []struct {
+		Address string `json:"address"`
+		Mode    string `json:"mode"`
+		Type    string `json:"type"`
+		Values  struct {
+			ID string `json:"id"`
+		} `json:"values"`
+	} `json:"resources"`
+	ChildModules []stateModule `json:"child_modules"`
+}
+
+// ReadState returns the gotify_application resources of r, the output of
+// terraform show -json, declared in module or its children. An empty module
+// selects every resource.
+func ReadState(r io.Reader, module string) ([]ManagedApplication, error) {
+	var state struct {
+		Values struct {
+			RootModule stateModule `json:"root_module"`
+		} `json:"values"`
+	}
+
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return nil, fmt.Errorf("can't decode the state, expected the output of terraform show -json: %w", err)
+	}
+
+	var managed []ManagedApplication
+	if err := collectManaged(state.Values.RootModule, module, &managed); err != nil {
+		return nil, err
+	}
+
+	return managed, nil
+}
+
+// collectManaged appends the gotify_application resources of m and its
+// children declared in module to managed.
+func collectManaged(m stateModule, module string, managed *[]ManagedApplication) error {
+	for _, resource := range m.Resources {
+		if resource.Mode != "managed" || resource.Type != "gotify_application" {
+			continue
+		}
+
+		if module != "" && !strings.HasPrefix(resource.Address, module+".") {
+			continue
+		}
+
+		id, err := strconv.ParseInt(resource.Values.ID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s has an invalid id %q", resource.Address, resource.Values.ID)
+		}
+
+		*managed = append(*managed, ManagedApplication{Address: resource.Address, ID: id})
+	}
+
+	for _, child := range m.ChildModules {
+		if err := collectManaged(child, module, managed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package generate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testState = `{
+  "format_version": "1.0",
+  "values": {
+    "root_module": {
+      "resources": [
+        {"address": "gotify_application.deploys", "mode": "managed", "type": "gotify_application", "values": {"id": "3"}},
+        {"address": "data.gotify_application.existing", "mode": "data", "type": "gotify_application", "values": {"id": "5"}}
+      ],
+      "child_modules": [
+        {
+          "address": "module.team_a",
+          "resources": [
+            {"address": "module.team_a.gotify_application.backups", "mode": "managed", "type": "gotify_application", "values": {"id": "2"}},
+            {"address": "module.team_a.gotify_client.ci", "mode": "managed", "type": "gotify_client", "values": {"id": "1"}}
+          ],
+          "child_modules": [
+            {
+              "address": "module.team_a.module.nested",
+              "resources": [
+                {"address": "module.team_a.module.nested.gotify_application.alerts[0]", "mode": "managed", "type": "gotify_application", "values": {"id": "7"}}
+              ]
+            }
+          ]
+        }
+      ]
+    }
+  }
+}`
+
+func TestReadState(t *testing.T) {
+	tests := map[string]struct {
+		module   string
+		expected []ManagedApplication
+	}{
+		"every module": {
+			expected: []ManagedApplication{
+				{Address: "gotify_application.deploys", ID: 3},
+				{Address: "module.team_a.gotify_application.backups", ID: 2},
+				{Address: "module.team_a.module.nested.gotify_application.alerts[0]", ID: 7},
+			},
+		},
+		"one module": {
+			module: "module.team_a.module.nested",
+			expected: []ManagedApplication{
+				{Address: "module.team_a.module.nested.gotify_application.alerts[0]", ID: 7},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			managed, err := ReadState(strings.NewReader(testState), test.module)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(managed, test.expected) {
+				t.Fatalf("expected %+v, got %+v", test.expected, managed)
+			}
+		})
+	}
+
+	if _, err := ReadState(strings.NewReader(`{"values":`), ""); err == nil {
+		t.Fatal("expected an error for an invalid state")
+	}
+}
+
+func TestManagedApplicationModule(t *testing.T) {
+	tests := map[string]string{
+		"gotify_application.backups":                               "",
+		"module.team_a.gotify_application.backups":                 "module.team_a",
+		"module.team_a.module.nested.gotify_application.alerts[0]": "module.team_a.module.nested",
+		`module.teams["a"].gotify_application.backups`:             `module.teams["a"]`,
+	}
+
+	for address, expected := range tests {
+		if got := (ManagedApplication{Address: address}).Module(); got != expected {
+			t.Errorf("expected %q for %s, got %q", expected, address, got)
+		}
+	}
+}
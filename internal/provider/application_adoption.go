@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// adoptionTimeout bounds the lookup of an application created by a request
+// whose answer was lost, which happens once the create timeout may have
+// elapsed.
+const adoptionTimeout = 30 * time.Second
+
+// applicationResponse is an application as answered by Gotify.
+type applicationResponse struct {
+	ID              int64   `json:"id"`
+	Token           string  `json:"token"`
+	Name            string  `json:"name"`
+	Description     string  `json:"description"`
+	Internal        bool    `json:"internal"`
+	Image           string  `json:"image"`
+	DefaultPriority int64   `json:"defaultPriority"`
+	LastUsed        *string `json:"lastUsed"`
+}
+
+// listApplications returns the applications of the user ctx authenticates
+// as.
+func (c *GotifyClient) listApplications(ctx context.Context) ([]applicationResponse, error) {
+	url := strings.Trim(c.Config.Url.String(), "\"")
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url+"/application", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpRes, err := c.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpRes.Body.Close()
+
+	if err := checkResponse(httpRes); err != nil {
+		return nil, err
+	}
+
+	var applications []applicationResponse
+	if err := c.decode(httpRes.Body, &applications); err != nil {
+		return nil, err
+	}
+
+	return applications, nil
+}
+
+// applicationSnapshot is what is known of the applications before a create
+// request, to tell the application it created from those existing before.
+type applicationSnapshot struct {
+	// latestID is the highest application id, or -1 when unknown. Gotify
+	// allocates ids in increasing order.
+	latestID int64
+	// taken is when the snapshot was taken.
+	taken time.Time
+}
+
+// snapshotApplications lists the applications before a create request. A
+// failed listing only disables the adoption of the application created by
+// a request whose answer is lost.
+func (c *GotifyClient) snapshotApplications(ctx context.Context) applicationSnapshot {
+	snapshot := applicationSnapshot{latestID: -1, taken: time.Now()}
+
+	applications, err := c.listApplications(ctx)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Can't list applications, an application created by a request whose answer is lost won't be adopted: %s", err))
+		return snapshot
+	}
+
+	snapshot.latestID = 0
+	for _, application := range applications {
+		if application.ID > snapshot.latestID {
+			snapshot.latestID = application.ID
+		}
+	}
+
+	return snapshot
+}
+
+// answerLost reports whether a create request failed with err, or was
+// answered by httpRes, in a way which doesn't tell whether Gotify created
+// the application: a timeout once connected, or a gateway error of a
+// reverse proxy. Other failures, e.g. refused connections or timeouts
+// while connecting, never reached Gotify.
+func answerLost(httpRes *http.Response, err error) bool {
+	if err != nil {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return false
+		}
+
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout()
+	}
+
+	return httpRes.StatusCode == http.StatusBadGateway || httpRes.StatusCode == http.StatusGatewayTimeout
+}
+
+// findCreatedApplication returns the application created since snapshot
+// with name, description and priority, as a create request whose answer
+// was lost would have. A created application has a higher id than those of
+// the snapshot, and can't have been used before it. Several candidates are
+// ambiguous and none is returned.
+func findCreatedApplication(applications []applicationResponse, snapshot applicationSnapshot, name string, description string, priority int64) (applicationResponse, bool) {
+	if snapshot.latestID < 0 {
+		return applicationResponse{}, false
+	}
+
+	var found []applicationResponse
+	for _, application := range applications {
+		if application.ID <= snapshot.latestID || application.Internal || application.Name != name || application.Description != description || application.DefaultPriority != priority {
+			continue
+		}
+
+		if application.LastUsed != nil {
+			lastUsed, err := time.Parse(time.RFC3339, *application.LastUsed)
+			if err != nil || lastUsed.Before(snapshot.taken) {
+				continue
+			}
+		}
+
+		found = append(found, application)
+	}
+
+	if len(found) != 1 {
+		return applicationResponse{}, false
+	}
+
+	return found[0], true
+}
+
+// adoptCreatedApplication returns the application Gotify created for a
+// create request answered by httpRes or failed with err, when the answer
+// was lost. snapshot was taken before the request.
+func (r *ApplicationResource) adoptCreatedApplication(ctx context.Context, httpRes *http.Response, err error, snapshot applicationSnapshot, name string, description string, priority int64) (applicationResponse, bool) {
+	if snapshot.latestID < 0 || !answerLost(httpRes, err) {
+		return applicationResponse{}, false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, adoptionTimeout)
+	defer cancel()
+
+	applications, listErr := r.client.listApplications(ctx)
+	if listErr != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Can't list applications to adopt the one created by a request whose answer was lost: %s", listErr))
+		return applicationResponse{}, false
+	}
+
+	return findCreatedApplication(applications, snapshot, name, description, priority)
+}
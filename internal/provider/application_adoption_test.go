@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestFindCreatedApplication(t *testing.T) {
+	taken := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	before := taken.Add(-time.Hour).Format(time.RFC3339)
+	after := taken.Add(time.Second).Format(time.RFC3339)
+
+	applications := []applicationResponse{
+		{ID: 1, Name: "alerts", Description: "Alerts", DefaultPriority: 5},
+		{ID: 3, Name: "alerts", Description: "Alerts", DefaultPriority: 5},
+		{ID: 2, Name: "backups", DefaultPriority: 2},
+		{ID: 4, Name: "reports", DefaultPriority: 1, LastUsed: &before},
+		{ID: 5, Name: "deploys", DefaultPriority: 1, LastUsed: &after},
+		{ID: 6, Name: "builds", DefaultPriority: 1},
+		{ID: 7, Name: "builds", DefaultPriority: 1},
+	}
+	snapshot := applicationSnapshot{latestID: 2, taken: taken}
+
+	if application, ok := findCreatedApplication(applications, snapshot, "alerts", "Alerts", 5); !ok || application.ID != 3 {
+		t.Fatalf("expected the application 3, got %+v (%t)", application, ok)
+	}
+
+	// An identical application existing before the request isn't adopted,
+	// even when it is the latest one.
+	if _, ok := findCreatedApplication(applications[:3], snapshot, "backups", "", 2); ok {
+		t.Fatal("expected applications existing before the request not to be adopted")
+	}
+
+	// An application used before the request existed before it.
+	if _, ok := findCreatedApplication(applications, snapshot, "reports", "", 1); ok {
+		t.Fatal("expected applications used before the request not to be adopted")
+	}
+
+	if application, ok := findCreatedApplication(applications, snapshot, "deploys", "", 1); !ok || application.ID != 5 {
+		t.Fatalf("expected the application 5 used since the request, got %+v (%t)", application, ok)
+	}
+
+	if _, ok := findCreatedApplication(applications, snapshot, "builds", "", 1); ok {
+		t.Fatal("expected ambiguous applications not to be adopted")
+	}
+
+	if _, ok := findCreatedApplication(applications, applicationSnapshot{latestID: -1, taken: taken}, "alerts", "Alerts", 5); ok {
+		t.Fatal("expected nothing to be adopted without a snapshot")
+	}
+
+	if _, ok := findCreatedApplication(nil, snapshot, "alerts", "Alerts", 5); ok {
+		t.Fatal("expected nothing to be adopted without applications")
+	}
+}
+
+func TestAnswerLost(t *testing.T) {
+	tests := map[string]struct {
+		status   int
+		err      error
+		expected bool
+	}{
+		"timeout":             {err: &redactedError{err: fmt.Errorf("POST /application: %w", context.DeadlineExceeded)}, expected: true},
+		"gateway timeout":     {status: http.StatusGatewayTimeout, expected: true},
+		"bad gateway":         {status: http.StatusBadGateway, expected: true},
+		"created":             {status: http.StatusOK},
+		"server error":        {status: http.StatusInternalServerError},
+		"dial timeout":        {err: &redactedError{err: &net.OpError{Op: "dial", Err: context.DeadlineExceeded}}},
+		"read timeout":        {err: &redactedError{err: &net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}}, expected: true},
+		"connection refused":  {err: &redactedError{err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}}},
+		"unresolved host":     {err: &redactedError{err: &net.DNSError{Name: "gotify.example.com", IsNotFound: true}}},
+		"missing credentials": {err: fmt.Errorf("POST /application requires authentication: %w", errMissingCredentials)},
+		"redirect refused":    {err: errors.New("redirects aren't followed")},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var httpRes *http.Response
+			if test.err == nil {
+				httpRes = &http.Response{StatusCode: test.status}
+			}
+
+			if lost := answerLost(httpRes, test.err); lost != test.expected {
+				t.Fatalf("expected answerLost to be %t, got %t", test.expected, lost)
+			}
+		})
+	}
+}
@@ -5,9 +5,7 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -27,16 +25,22 @@ func NewApplicationDataSource() datasource.DataSource {
 
 // ApplicationDataSource defines the data source implementation.
 type ApplicationDataSource struct {
-	client *http.Client
+	client *GotifyClient
 }
 
 // ApplicationDataSourceModel describes the data source data model.
 type ApplicationDataSourceModel struct {
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Priority    types.String `tfsdk:"priority"`
-	Id          types.String `tfsdk:"id"`
-	Token       types.String `tfsdk:"token"`
+	Name        types.String  `tfsdk:"name"`
+	Description types.String  `tfsdk:"description"`
+	Priority    PriorityValue `tfsdk:"priority"`
+	Id          types.String  `tfsdk:"id"`
+	Token       types.String  `tfsdk:"token"`
+	IgnoreCase  types.Bool    `tfsdk:"ignore_case"`
+
+	ApplicationId types.Int64 `tfsdk:"application_id"`
+
+	FailIfMissing types.Bool `tfsdk:"fail_if_missing"`
+	Found         types.Bool `tfsdk:"found"`
 }
 
 func (d *ApplicationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -50,25 +54,46 @@ func (d *ApplicationDataSource) Schema(ctx context.Context, req datasource.Schem
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Name of the gotify application you want to create",
+				MarkdownDescription: "Name of the gotify application to look up. Required unless `id` is set",
 				Optional:            true,
+				Computed:            true,
 			},
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Description of the gotify application",
 				Optional:            true,
+				Computed:            true,
 			},
 			"priority": schema.StringAttribute{
 				MarkdownDescription: "Priority of the application",
+				CustomType:          PriorityType{},
 				Optional:            true,
+				Computed:            true,
 			},
 			"id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Application identifier",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Application identifier. Required unless `name` is set",
+			},
+			"application_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Application identifier, as a number",
 			},
 			"token": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Application identifier",
 			},
+			"ignore_case": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Compare `name` to the application names ignoring case. Defaults to `false`",
+			},
+			"fail_if_missing": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Fail when no application matches. When `false`, `found` is set to `false` and the application attributes are left null instead. Defaults to `true`",
+			},
+			"found": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether an application matched",
+			},
 		},
 	}
 }
@@ -78,12 +103,12 @@ func (d *ApplicationDataSource) Configure(ctx context.Context, req datasource.Co
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*GotifyClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *GotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -102,40 +127,38 @@ func (d *ApplicationDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	url := strings.Trim(Config.Url.String(), "\"")
-	token := strings.Trim(Config.Token.String(), "\"")
+	if data.Id.IsNull() == data.Name.IsNull() {
+		resp.Diagnostics.AddError("Invalid application lookup", "Exactly one of id and name must be set")
+		return
+	}
+
+	ctx, cancel := d.client.operationContext(ctx, "read", nil)
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	url := strings.Trim(d.client.Config.Url.String(), "\"")
 	id := strings.Trim(data.Id.String(), "\"")
+	name := data.Name.ValueString()
 
-	httpReq, err := http.NewRequest("GET", url+"/application", nil)
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url+"/application", nil)
 	if err != nil {
 		tflog.Error(ctx, err.Error())
 		resp.Diagnostics.AddError("Can't send request to Gotify", err.Error())
 		return
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("X-Gotify-Key", token)
 
 	httpRes, err := d.client.Do(httpReq)
 	if err != nil {
 		tflog.Error(ctx, err.Error())
-		resp.Diagnostics.AddError("API Error when contacting Gotify instance", err.Error())
+		d.client.addRequestError(&resp.Diagnostics, err)
 		return
 	}
 	defer httpRes.Body.Close()
 
-	statusCode := httpRes.StatusCode
-
-	if statusCode == 401 {
-		bodyBytes, _ := io.ReadAll(httpRes.Body)
-		bodyString := string(bodyBytes)
-
-		resp.Diagnostics.AddError("Not Allowed", fmt.Sprintf("Bad token (?) : %s", bodyString))
-		return
-	} else if statusCode != 200 {
-		bodyBytes, _ := io.ReadAll(httpRes.Body)
-		bodyString := string(bodyBytes)
-
-		resp.Diagnostics.AddError("API Error when contacting Gotify instance", fmt.Sprintf("Received a %s response code : %s", strconv.Itoa(statusCode), bodyString))
+	if err := checkResponse(httpRes); err != nil {
+		d.client.addResponseError(&resp.Diagnostics, err)
 		return
 	}
 
@@ -152,39 +175,63 @@ func (d *ApplicationDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 	var respData JsonReponse
 
-	err = json.NewDecoder(httpRes.Body).Decode(&respData)
+	err = d.client.decode(httpRes.Body, &respData)
 	if err != nil {
 		resp.Diagnostics.AddError("API Error when contacting Gotify instance", err.Error())
 		return
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Searched id: %s", id))
-
-	ok := false
-	for _, Application := range respData {
-		if strconv.Itoa(int(Application.ID)) == id {
-			ok = true
-			data.Name = types.StringValue(Application.Name)
-			data.Description = types.StringValue(Application.Description)
-			data.Id = types.StringValue(strconv.FormatInt(Application.ID, 10))
-			data.Priority = types.StringValue(strconv.FormatInt(Application.DefaultPriority, 10))
-			data.Token = types.StringValue(Application.Token)
+	if data.Id.IsNull() {
+		tflog.Info(ctx, fmt.Sprintf("Searched name: %s", name))
+	} else {
+		tflog.Info(ctx, fmt.Sprintf("Searched id: %s", id))
+	}
+
+	var matches []int
+	for i, Application := range respData {
+		if data.Id.IsNull() {
+			if Application.Name == name || (data.IgnoreCase.ValueBool() && strings.EqualFold(Application.Name, name)) {
+				matches = append(matches, i)
+			}
+		} else if strconv.Itoa(int(Application.ID)) == id {
+			matches = append(matches, i)
 		}
 	}
 
-	if !ok {
-		resp.Diagnostics.AddError("API Error", "No application found with this id")
+	if len(matches) == 0 && !data.FailIfMissing.IsNull() && !data.FailIfMissing.ValueBool() {
+		data.Found = types.BoolValue(false)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	if len(matches) == 0 {
+		if data.Id.IsNull() {
+			resp.Diagnostics.AddError("API Error", "No application found with this name")
+		} else {
+			resp.Diagnostics.AddError("API Error", "No application found with this id")
+		}
+		return
+	}
+
+	if len(matches) > 1 {
+		ids := make([]string, 0, len(matches))
+		for _, i := range matches {
+			ids = append(ids, fmt.Sprintf("%d (%s)", respData[i].ID, respData[i].Name))
+		}
+
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Several applications match the name %s: %s", name, strings.Join(ids, ", ")))
 		return
 	}
 
-	// data.Description = types.StringValue("Description")
-	// data.Id = types.StringValue("Application-id")
-	// data.Priority = types.StringValue("Priority")
-	// data.Token = types.StringValue("Token")
-	// data.Name = types.StringValue("Name")
+	Application := respData[matches[0]]
+	data.Name = types.StringValue(Application.Name)
+	data.Description = types.StringValue(Application.Description)
+	data.Id = types.StringValue(strconv.FormatInt(Application.ID, 10))
+	data.ApplicationId = types.Int64Value(Application.ID)
+	data.Priority = NewPriorityValueInt64(Application.DefaultPriority)
+	data.Token = types.StringValue(Application.Token)
+	data.Found = types.BoolValue(true)
 
-	// Write logs using the tflog package
-	// Documentation: https://terraform.io/plugin/log
 	tflog.Trace(ctx, "read a data source")
 
 	// Save data into Terraform state
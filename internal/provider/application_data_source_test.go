@@ -4,29 +4,216 @@
 package provider
 
 import (
+	"context"
+	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
-func TestAccExampleDataSource(t *testing.T) {
+func TestAccApplicationDataSource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Read testing
 			{
-				Config: testAccExampleDataSourceConfig,
+				Config: testAccProviderConfig() + testAccApplicationDataSourceConfig,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.gotify_application.test", "id", "example-id"),
+					resource.TestCheckResourceAttrPair("data.gotify_application.by_name", "id", "gotify_application.test", "id"),
+					resource.TestCheckResourceAttrPair("data.gotify_application.by_name", "token", "gotify_application.test", "token"),
+					resource.TestCheckResourceAttr("data.gotify_application.by_name", "name", "tf-acc-data-source"),
+					resource.TestCheckResourceAttr("data.gotify_application.by_name", "description", "Read by the acceptance tests"),
+					resource.TestCheckResourceAttr("data.gotify_application.by_name", "priority", "6"),
+					resource.TestCheckResourceAttr("data.gotify_application.by_name", "found", "true"),
+					resource.TestCheckResourceAttrPair("data.gotify_application.by_id", "name", "gotify_application.test", "name"),
+					resource.TestCheckResourceAttrPair("data.gotify_application.by_id", "application_id", "gotify_application.test", "application_id"),
+					resource.TestCheckResourceAttr("data.gotify_application.missing", "found", "false"),
+					resource.TestCheckNoResourceAttr("data.gotify_application.missing", "token"),
 				),
 			},
 		},
 	})
 }
 
-const testAccExampleDataSourceConfig = `
-data "gotify_application" "test" {
-  configurable_attribute = "example"
+const testAccApplicationDataSourceConfig = `
+resource "gotify_application" "test" {
+  name             = "tf-acc-data-source"
+  description      = "Read by the acceptance tests"
+  default_priority = "6"
+}
+
+data "gotify_application" "by_name" {
+  name        = upper(gotify_application.test.name)
+  ignore_case = true
+}
+
+data "gotify_application" "by_id" {
+  id = gotify_application.test.id
+}
+
+data "gotify_application" "missing" {
+  name            = "tf-acc-missing-${gotify_application.test.id}"
+  fail_if_missing = false
 }
 `
+
+// readApplicationDataSource reads the gotify_application data source
+// configured with config against fake.
+func readApplicationDataSource(t *testing.T, fake *fakeGotify, config ApplicationDataSourceModel) (ApplicationDataSourceModel, *datasource.ReadResponse) {
+	ctx := context.Background()
+
+	d := &ApplicationDataSource{
+		client: &GotifyClient{
+			Client: &http.Client{Transport: &handlerTransport{handler: fake}},
+			Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("can't build config: %v", diags)
+	}
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: state.Raw}}
+
+	d.Read(ctx, req, resp)
+
+	var data ApplicationDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	}
+
+	return data, resp
+}
+
+// applicationLookup returns the configuration of a gotify_application data
+// source looking up name.
+func applicationLookup(name string) ApplicationDataSourceModel {
+	return ApplicationDataSourceModel{
+		Name:          types.StringValue(name),
+		Description:   types.StringNull(),
+		Priority:      PriorityValue{StringValue: types.StringNull()},
+		Id:            types.StringNull(),
+		Token:         types.StringNull(),
+		IgnoreCase:    types.BoolNull(),
+		ApplicationId: types.Int64Null(),
+		FailIfMissing: types.BoolNull(),
+		Found:         types.BoolNull(),
+	}
+}
+
+func TestApplicationDataSourceIgnoreCase(t *testing.T) {
+	tests := map[string]struct {
+		name         string
+		ignoreCase   types.Bool
+		duplicate    bool
+		expectedID   string
+		expectedName string
+		wantError    string
+	}{
+		"exact name":          {name: "Backups", ignoreCase: types.BoolNull(), expectedID: "1", expectedName: "Backups"},
+		"other case":          {name: "backups", ignoreCase: types.BoolNull(), wantError: "No application found with this name"},
+		"other case disabled": {name: "backups", ignoreCase: types.BoolValue(false), wantError: "No application found with this name"},
+		"ignore case":         {name: "BACKUPS", ignoreCase: types.BoolValue(true), expectedID: "1", expectedName: "Backups"},
+		"duplicate folded":    {name: "backups", ignoreCase: types.BoolValue(true), duplicate: true, wantError: "Several applications match the name backups: 1 (Backups), 3 (backups)"},
+		"duplicate exact":     {name: "Backups", ignoreCase: types.BoolNull(), duplicate: true, expectedID: "1", expectedName: "Backups"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake := newFakeGotify()
+			fake.applications[1] = &fakeApplication{ID: 1, Token: "ABackups", Name: "Backups", DefaultPriority: 5}
+			fake.applications[2] = &fakeApplication{ID: 2, Token: "AAlerts", Name: "alerts", DefaultPriority: 8}
+			if test.duplicate {
+				fake.applications[3] = &fakeApplication{ID: 3, Token: "ABackupsAgain", Name: "backups", DefaultPriority: 5}
+			}
+
+			config := applicationLookup(test.name)
+			config.IgnoreCase = test.ignoreCase
+
+			data, resp := readApplicationDataSource(t, fake, config)
+
+			if test.wantError != "" {
+				errs := resp.Diagnostics.Errors()
+				if len(errs) != 1 || errs[0].Detail() != test.wantError {
+					t.Fatalf("expected the error %q, got %v", test.wantError, resp.Diagnostics)
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if data.Id.ValueString() != test.expectedID || data.Name.ValueString() != test.expectedName || !data.Found.ValueBool() {
+				t.Fatalf("expected the application %s (%s), got %+v", test.expectedID, test.expectedName, data)
+			}
+		})
+	}
+}
+
+func TestApplicationDataSourceFailIfMissing(t *testing.T) {
+	tests := map[string]struct {
+		name          string
+		id            string
+		failIfMissing types.Bool
+		expectedFound bool
+		wantError     string
+	}{
+		"found":                {name: "alerts", failIfMissing: types.BoolValue(false), expectedFound: true},
+		"missing name":         {name: "backups", failIfMissing: types.BoolNull(), wantError: "No application found with this name"},
+		"missing name failing": {name: "backups", failIfMissing: types.BoolValue(true), wantError: "No application found with this name"},
+		"missing name allowed": {name: "backups", failIfMissing: types.BoolValue(false)},
+		"missing id":           {id: "9", failIfMissing: types.BoolNull(), wantError: "No application found with this id"},
+		"missing id allowed":   {id: "9", failIfMissing: types.BoolValue(false)},
+		"found by id, allowed": {id: "2", failIfMissing: types.BoolValue(false), expectedFound: true},
+		"found by id, failing": {id: "2", failIfMissing: types.BoolValue(true), expectedFound: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake := newFakeGotify()
+			fake.applications[2] = &fakeApplication{ID: 2, Token: "AAlerts", Name: "alerts", Description: "Alerts", DefaultPriority: 8}
+
+			config := applicationLookup(test.name)
+			if test.id != "" {
+				config.Name = types.StringNull()
+				config.Id = types.StringValue(test.id)
+			}
+			config.FailIfMissing = test.failIfMissing
+
+			data, resp := readApplicationDataSource(t, fake, config)
+
+			if test.wantError != "" {
+				errs := resp.Diagnostics.Errors()
+				if len(errs) != 1 || errs[0].Detail() != test.wantError {
+					t.Fatalf("expected the error %q, got %v", test.wantError, resp.Diagnostics)
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if data.Found.ValueBool() != test.expectedFound {
+				t.Fatalf("expected found to be %t, got %s", test.expectedFound, data.Found)
+			}
+
+			// Missing applications leave their attributes null.
+			if !test.expectedFound && (!data.Token.IsNull() || !data.ApplicationId.IsNull() || !data.Description.IsNull() || !data.Priority.IsNull()) {
+				t.Fatalf("expected null attributes, got %+v", data)
+			}
+			if test.expectedFound && (data.Token.ValueString() != "AAlerts" || data.ApplicationId.ValueInt64() != 2 || data.Priority.ValueString() != "8") {
+				t.Fatalf("expected the application 2, got %+v", data)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// applicationDescription renders the tags describing the owner, environment
+// and repository of an application, e.g.
+// "owner:payments env:production repo:https://github.com/acme/payments".
+// repository is left out when empty.
+func applicationDescription(owner string, environment string, repository string) (string, error) {
+	tags := []string{}
+
+	for _, tag := range []struct{ name, value string }{{"owner", owner}, {"env", environment}} {
+		if tag.value == "" || strings.IndexFunc(tag.value, unicode.IsSpace) >= 0 {
+			return "", fmt.Errorf("%s must be a non empty value without whitespace, got %q", tag.name, tag.value)
+		}
+		tags = append(tags, fmt.Sprintf("%s:%s", tag.name, tag.value))
+	}
+
+	if repository != "" {
+		if err := validateURL(repository); err != nil {
+			return "", fmt.Errorf("repository must be a link to the repository: %w", err)
+		}
+		tags = append(tags, fmt.Sprintf("repo:%s", repository))
+	}
+
+	return strings.Join(tags, " "), nil
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ApplicationDescriptionFunction{}
+
+func NewApplicationDescriptionFunction() function.Function {
+	return &ApplicationDescriptionFunction{}
+}
+
+// ApplicationDescriptionFunction defines the function implementation.
+type ApplicationDescriptionFunction struct{}
+
+func (f *ApplicationDescriptionFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "application_description"
+}
+
+func (f *ApplicationDescriptionFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Standard description of an application",
+		MarkdownDescription: "Renders the description of an application tagged with its owner, environment and repository, e.g. `owner:payments env:production repo:https://github.com/acme/payments`, which `gotify_applications` can filter on with `description_contains`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "owner",
+				MarkdownDescription: "Team owning the application, e.g. `payments`",
+			},
+			function.StringParameter{
+				Name:                "environment",
+				MarkdownDescription: "Environment of the application, e.g. `production`",
+			},
+			function.StringParameter{
+				Name:                "repository",
+				MarkdownDescription: "Link to the repository managing the application, or an empty string",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ApplicationDescriptionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var owner, environment, repository string
+
+	resp.Diagnostics.Append(req.Arguments.Get(ctx, &owner, &environment, &repository)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	description, err := applicationDescription(owner, environment, repository)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid application description", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, description)...)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestApplicationDescriptionFunction(t *testing.T) {
+	tests := map[string]struct {
+		owner       string
+		environment string
+		repository  string
+		expected    string
+		wantErr     bool
+	}{
+		"all tags":          {owner: "payments", environment: "production", repository: "https://github.com/acme/payments", expected: "owner:payments env:production repo:https://github.com/acme/payments"},
+		"no repository":     {owner: "payments", environment: "staging", expected: "owner:payments env:staging"},
+		"empty owner":       {environment: "production", wantErr: true},
+		"owner with spaces": {owner: "team payments", environment: "production", wantErr: true},
+		"invalid link":      {owner: "payments", environment: "production", repository: "acme/payments", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(test.owner),
+					types.StringValue(test.environment),
+					types.StringValue(test.repository),
+				}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewApplicationDescriptionFunction().Run(context.Background(), req, &resp)
+
+			if test.wantErr {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !resp.Result.Equal(function.NewResultData(types.StringValue(test.expected))) {
+				t.Fatalf("expected %q, got %s", test.expected, resp.Result.Value())
+			}
+		})
+	}
+}
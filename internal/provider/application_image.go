@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxImageSize is the largest icon the provider will upload to Gotify.
+const maxImageSize = 10 * 1024 * 1024
+
+// defaultImage is the image of the applications no icon was uploaded for.
+const defaultImage = "static/defaultapp.png"
+
+// supportedImageTypes are the content types Gotify accepts as application icons.
+var supportedImageTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+}
+
+// validateImageFile checks that the file at path exists, is a png, jpeg or
+// gif image and is small enough to be uploaded.
+func validateImageFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("can't read image %s: %w", path, err)
+	}
+
+	if info.IsDir() {
+		return fmt.Errorf("image %s is a directory", path)
+	}
+
+	if info.Size() > maxImageSize {
+		return fmt.Errorf("image %s is %d bytes, the maximum allowed size is %d bytes", path, info.Size(), maxImageSize)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("can't read image %s: %w", path, err)
+	}
+	defer file.Close()
+
+	// DetectContentType never looks at more than the first 512 bytes.
+	header := make([]byte, 512)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return fmt.Errorf("can't read image %s: %w", path, err)
+	}
+
+	contentType := http.DetectContentType(header[:n])
+	if !supportedImageTypes[contentType] {
+		return fmt.Errorf("image %s has unsupported type %s, only png, jpeg and gif images are supported", path, contentType)
+	}
+
+	return nil
+}
+
+// newImageUploadRequest builds the multipart request uploading the file at
+// path as the icon of the application located at url.
+func newImageUploadRequest(ctx context.Context, url string, path string) (*http.Request, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read image %s: %w", path, err)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := part.Write(content); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return httpReq, nil
+}
+
+// imageHash describes the content of the file at path in a line short enough
+// for plans: the first 12 hexadecimal digits of its SHA-256 hash, and its
+// size.
+func imageHash(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("can't read image %s: %w", path, err)
+	}
+
+	return contentHash(content), nil
+}
+
+// contentHash describes content as imageHash does.
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return fmt.Sprintf("sha256:%x (%d bytes)", sum[:6], len(content))
+}
+
+// remoteImageHash describes the icon Gotify serves at image, the path of an
+// application icon relative to the url of the provider, as imageHash does.
+// Gotify stores uploaded icons as is, so it matches the hash of the file
+// they were uploaded from.
+func (c *GotifyClient) remoteImageHash(ctx context.Context, image string) (string, error) {
+	url := strings.Trim(c.Config.Url.String(), "\"")
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url+"/"+image, nil)
+	if err != nil {
+		return "", err
+	}
+
+	httpRes, err := c.Do(httpReq)
+	if err != nil {
+		return "", err
+	}
+	defer httpRes.Body.Close()
+
+	if err := checkResponse(httpRes); err != nil {
+		return "", err
+	}
+
+	content, err := io.ReadAll(io.LimitReader(httpRes.Body, maxImageSize+1))
+	if err != nil {
+		return "", err
+	}
+
+	return contentHash(content), nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateImageFile(t *testing.T) {
+	dir := t.TempDir()
+
+	png := filepath.Join(dir, "icon.png")
+	if err := os.WriteFile(png, []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	text := filepath.Join(dir, "icon.txt")
+	if err := os.WriteFile(text, []byte("not an image"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	large := filepath.Join(dir, "large.gif")
+	if err := os.WriteFile(large, append([]byte("GIF89a"), make([]byte, maxImageSize)...), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		path    string
+		wantErr string
+	}{
+		"png":         {path: png},
+		"unsupported": {path: text, wantErr: "unsupported type"},
+		"too large":   {path: large, wantErr: "maximum allowed size"},
+		"missing":     {path: filepath.Join(dir, "missing.png"), wantErr: "can't read image"},
+		"directory":   {path: dir, wantErr: "is a directory"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateImageFile(test.path)
+
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", test.wantErr, err)
+			}
+
+			if !strings.Contains(err.Error(), test.path) {
+				t.Fatalf("expected error to mention %s, got %s", test.path, err)
+			}
+		})
+	}
+}
+
+func TestImageHash(t *testing.T) {
+	png := filepath.Join(t.TempDir(), "icon.png")
+	if err := os.WriteFile(png, []byte("\x89PNG\r\n\x1a\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := imageHash(png)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hash != "sha256:4c4b6a3be131 (8 bytes)" {
+		t.Fatalf("unexpected hash %s", hash)
+	}
+
+	if _, err := imageHash(filepath.Join(t.TempDir(), "missing.png")); err == nil {
+		t.Fatal("expected an error for a missing image")
+	}
+}
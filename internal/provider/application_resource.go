@@ -7,15 +7,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -26,6 +30,26 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ApplicationResource{}
 var _ resource.ResourceWithImportState = &ApplicationResource{}
+var _ resource.ResourceWithValidateConfig = &ApplicationResource{}
+var _ resource.ResourceWithModifyPlan = &ApplicationResource{}
+
+// Placeholder values of the attributes left out of the configuration.
+const (
+	defaultDescription = "Description not configured"
+	defaultPriority    = "1"
+)
+
+// imageUploadAttempts is the number of times an image upload failing on the
+// network or with a server error is attempted.
+const imageUploadAttempts = 3
+
+// imageUploadRetryInterval is the time waited between two attempts to upload
+// an image.
+var imageUploadRetryInterval = 2 * time.Second
+
+// priorityRename is the rename of priority to default_priority, the name
+// of the attribute in the Gotify API.
+var priorityRename = renamedAttribute[PriorityValue]{from: "priority", to: "default_priority"}
 
 func NewApplicationResource() resource.Resource {
 	return &ApplicationResource{}
@@ -33,16 +57,31 @@ func NewApplicationResource() resource.Resource {
 
 // ApplicationResource defines the resource implementation.
 type ApplicationResource struct {
-	client *http.Client
+	client *GotifyClient
 }
 
 // ApplicationResourceModel describes the resource data model.
 type ApplicationResourceModel struct {
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Priority    types.String `tfsdk:"priority"`
-	Id          types.String `tfsdk:"id"`
-	Token       types.String `tfsdk:"token"`
+	Name            types.String     `tfsdk:"name"`
+	Description     DescriptionValue `tfsdk:"description"`
+	Priority        PriorityValue    `tfsdk:"priority"`
+	DefaultPriority PriorityValue    `tfsdk:"default_priority"`
+	Id              types.String     `tfsdk:"id"`
+	Token           types.String     `tfsdk:"token"`
+	Image           types.String     `tfsdk:"image"`
+	ImageHash       types.String     `tfsdk:"image_hash"`
+
+	ApplicationId types.Int64 `tfsdk:"application_id"`
+
+	TokenRotationTrigger types.Map `tfsdk:"token_rotation_trigger"`
+
+	OwnerUsername types.String `tfsdk:"owner_username"`
+	OwnerPassword types.String `tfsdk:"owner_password"`
+	OwnerToken    types.String `tfsdk:"owner_token"`
+
+	VerifyToken types.Bool `tfsdk:"verify_token"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
 }
 
 func (r *ApplicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,7 +91,7 @@ func (r *ApplicationResource) Metadata(ctx context.Context, req resource.Metadat
 func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Application resource for gotify",
+		MarkdownDescription: "Application resource for gotify. Planning two applications with the same name fails; as providers don't know the addresses of resources, the error describes the applications by their id once created.",
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -61,16 +100,24 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 				Required:            true,
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Description of the gotify application",
+				MarkdownDescription: "Description of the gotify application. Differences in trailing whitespace and line endings are ignored",
+				CustomType:          DescriptionType{},
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("Description not configured"),
+				Default:             stringdefault.StaticString(defaultDescription),
 			},
 			"priority": schema.StringAttribute{
-				MarkdownDescription: "Priority of the application",
+				MarkdownDescription: "Deprecated name of `default_priority`",
+				CustomType:          PriorityType{},
+				Optional:            true,
+				Computed:            true,
+				DeprecationMessage:  priorityRename.deprecationMessage(),
+			},
+			"default_priority": schema.StringAttribute{
+				MarkdownDescription: "Priority of the messages sent by the application without a priority of their own, a whole number from 0. Defaults to `" + defaultPriority + "`",
+				CustomType:          PriorityType{},
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("1"),
 			},
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -79,6 +126,13 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"application_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Application identifier, as a number",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
 			"token": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Application identifier",
@@ -86,10 +140,203 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"image": schema.StringAttribute{
+				MarkdownDescription: "Path to a png, jpeg or gif file uploaded as the application icon. Defaults to `default_application_image_path` of the provider. When the upload fails once the application is created, it is reported as a warning and retried by the next apply",
+				Optional:            true,
+			},
+			"image_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Short SHA-256 hash and size of the uploaded icon, e.g. `sha256:3f2a9c1b7d4e (5120 bytes)`, so plans show when the content of the icon changes",
+			},
+			"token_rotation_trigger": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values which, when changed, recreates the application to rotate its token, e.g. with the id of a `time_rotating` resource",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"verify_token": schema.BoolAttribute{
+				MarkdownDescription: "Check on every refresh that `token` is still the token of the application, and refresh it when the application was given another one outside of Terraform. Defaults to `false`",
+				Optional:            true,
+			},
+			"owner_username": schema.StringAttribute{
+				MarkdownDescription: "Name of the Gotify user owning the application. The application is managed with `owner_username` and `owner_password` instead of the provider credentials. Changing it recreates the application",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"owner_password": schema.StringAttribute{
+				MarkdownDescription: "Password of the user set in `owner_username`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"owner_token": schema.StringAttribute{
+				MarkdownDescription: "Client token of the Gotify user owning the application, instead of `owner_username` and `owner_password`. Changing it recreates the application",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": schema.SingleNestedBlock{
+				MarkdownDescription: "Timeouts of the operations on the application, overriding the provider `default_timeouts`",
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{
+						MarkdownDescription: "Timeout of the creation, as a duration such as `30s` or `5m`",
+						Optional:            true,
+					},
+					"read": schema.StringAttribute{
+						MarkdownDescription: "Timeout of reads, as a duration such as `30s` or `5m`",
+						Optional:            true,
+					},
+					"update": schema.StringAttribute{
+						MarkdownDescription: "Timeout of updates, as a duration such as `30s` or `5m`",
+						Optional:            true,
+					},
+					"delete": schema.StringAttribute{
+						MarkdownDescription: "Timeout of the deletion, as a duration such as `30s` or `5m`",
+						Optional:            true,
+					},
+					"request": schema.StringAttribute{
+						MarkdownDescription: "Timeout of each call to Gotify, including reading its answer, as a duration such as `30s` or `5m`",
+						Optional:            true,
+					},
+					"upload": schema.StringAttribute{
+						MarkdownDescription: "Timeout of each upload of the image, as a duration such as `30s` or `5m`, so a large image can be given longer than the other calls. Defaults to the `request` timeout",
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
 
+func (r *ApplicationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ApplicationResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateTimeouts(data.Timeouts, path.Root("timeouts"))...)
+
+	resp.Diagnostics.Append(priorityRename.validate(ctx, req.Config)...)
+
+	resp.Diagnostics.Append(validateOwnerCredentials(data.OwnerUsername, data.OwnerPassword, data.OwnerToken)...)
+
+	// The path may come from another resource and only be known at apply time.
+	if data.Image.IsNull() || data.Image.IsUnknown() {
+		return
+	}
+
+	if err := validateImageFile(data.Image.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("image"), "Invalid application image", err.Error())
+	}
+}
+
+// ModifyPlan warns when the placeholder value of description or priority is
+// about to be applied, so applications don't silently end up with them.
+func (r *ApplicationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is applied when the application is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, plan ApplicationResourceModel
+	var state *ApplicationResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(priorityRename.resolve(ctx, req.Config, &resp.Plan, NewPriorityValue(defaultPriority))...)
+
+	configuredPriority, diags := priorityRename.configured(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Providers aren't told the addresses of resources, so the duplicates
+	// can only be told apart by their id once created. Applications of
+	// different owners can share a name.
+	if r.client != nil && r.client.plannedNames != nil && !plan.Name.IsUnknown() {
+		key := fmt.Sprintf("%s/%s/%s", plan.OwnerUsername.ValueString(), plan.OwnerToken.ValueString(), plan.Name.ValueString())
+		owner := "a new application"
+		if state != nil {
+			owner = fmt.Sprintf("the application with id %s", state.Id.ValueString())
+		}
+
+		// A replacement is planned again without its prior state, under the
+		// name it was already registered with. The attributes requiring it are
+		// compared here as the framework only returns them after ModifyPlan.
+		if state != nil && (!plan.TokenRotationTrigger.Equal(state.TokenRotationTrigger) || !plan.OwnerUsername.Equal(state.OwnerUsername) || !plan.OwnerToken.Equal(state.OwnerToken)) {
+			r.client.plannedNames.replace(key)
+		}
+
+		replacement := state == nil && r.client.plannedNames.replacement(key)
+
+		if previous, ok := r.client.plannedNames.register(key, owner); ok && !replacement {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Duplicate application name",
+				fmt.Sprintf("Both %s and %s are named %q in this configuration. Gotify allows it, but the applications can't be told apart by their name. Give each gotify_application a different name.", previous, owner, plan.Name.ValueString()),
+			)
+			return
+		}
+	}
+
+	// Icons are compared by content, so plans show when the file was changed
+	// in place. The icon is kept when image is removed.
+	if r.client != nil {
+		planned := types.StringNull()
+		if state != nil {
+			planned = state.ImageHash
+		}
+
+		// The hash of a new application is only known once its icon was
+		// uploaded, so a failed upload can leave it null.
+		if image := r.imagePath(plan.Image); image.IsUnknown() || (state == nil && !image.IsNull()) {
+			planned = types.StringUnknown()
+		} else if !image.IsNull() {
+			// Invalid images are reported by ValidateConfig.
+			if hash, err := imageHash(image.ValueString()); err == nil {
+				planned = types.StringValue(hash)
+			}
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("image_hash"), planned)...)
+	}
+
+	// Only warn when the placeholder is applied, not on every plan once it is.
+	if config.Description.IsNull() && (state == nil || state.Description.ValueString() != defaultDescription) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("description"),
+			"Placeholder description applied",
+			fmt.Sprintf("description isn't set, the application %s will be described as %q. Set description to silence this warning.", plan.Name.ValueString(), defaultDescription),
+		)
+	}
+
+	if configuredPriority.IsNull() && (state == nil || state.Priority.ValueString() != defaultPriority) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("default_priority"),
+			"Default priority applied",
+			fmt.Sprintf("default_priority isn't set, the messages of the application %s will default to priority %s. Set default_priority to silence this warning.", plan.Name.ValueString(), defaultPriority),
+		)
+	}
+}
+
 func (r *ApplicationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 
@@ -98,12 +345,12 @@ func (r *ApplicationResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*GotifyClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *GotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -115,26 +362,43 @@ func (r *ApplicationResource) Configure(ctx context.Context, req resource.Config
 func (r *ApplicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ApplicationResourceModel
 
+	resp.Diagnostics.Append(r.client.checkReadOnly("create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	url := strings.Trim(Config.Url.String(), "\"")
-	token := strings.Trim(Config.Token.String(), "\"")
+	// The application created by a request whose answer was lost is looked
+	// for once the create timeout may have elapsed.
+	lookupCtx := ownerContext(moduleContext(ctx, req.ProviderMeta), data.OwnerUsername, data.OwnerPassword, data.OwnerToken)
+
+	ctx, cancel := r.client.operationContext(ctx, "create", data.Timeouts)
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+	ctx = ownerContext(ctx, data.OwnerUsername, data.OwnerPassword, data.OwnerToken)
+
+	url := strings.Trim(r.client.Config.Url.String(), "\"")
 
-	priority, err := strconv.Atoi(strings.Trim(data.Priority.String(), "\""))
+	priority, err := data.Priority.Int64()
 	if err != nil {
 		tflog.Error(ctx, err.Error())
 		resp.Diagnostics.AddError("Priority cannot be parsed as Int", err.Error())
 		return
 	}
 
+	name := strings.Trim(data.Name.String(), "\"")
+	description := strings.Trim(data.Description.String(), "\"")
+
 	reqData := map[string]interface{}{
 		"defaultPriority": priority,
-		"description":     strings.Trim(data.Description.String(), "\""),
-		"name":            strings.Trim(data.Name.String(), "\""),
+		"description":     description,
+		"name":            name,
 	}
 
 	jsonData, err := json.Marshal(reqData)
@@ -144,57 +408,65 @@ func (r *ApplicationResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	httpReq, err := http.NewRequest("POST", url+"/application", bytes.NewBuffer(jsonData))
+	// The applications existing before the request can't be adopted, should
+	// its answer be lost.
+	snapshot := r.client.snapshotApplications(ctx)
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url+"/application", bytes.NewBuffer(jsonData))
 	if err != nil {
 		tflog.Error(ctx, err.Error())
 		resp.Diagnostics.AddError("Can't send request to Gotify", err.Error())
 		return
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("X-Gotify-Key", token)
+
+	var respData applicationResponse
 
 	httpRes, err := r.client.Do(httpReq)
-	if err != nil {
-		tflog.Error(ctx, err.Error())
-		resp.Diagnostics.AddError("API Error when contacting Gotify instance", err.Error())
-		return
+	if err == nil {
+		defer httpRes.Body.Close()
 	}
-	defer httpRes.Body.Close()
 
-	statusCode := httpRes.StatusCode
-
-	if statusCode == 401 {
-		bodyBytes, _ := ioutil.ReadAll(httpRes.Body)
-		bodyString := string(bodyBytes)
-
-		resp.Diagnostics.AddError("Not Allowed", fmt.Sprintf("Bad token (?) : %s", bodyString))
+	if adopted, ok := r.adoptCreatedApplication(lookupCtx, httpRes, err, snapshot, name, description, priority); ok {
+		resp.Diagnostics.AddWarning(
+			"Adopted application",
+			fmt.Sprintf("The answer to the creation of the application %s was lost, but Gotify created it with id %d. It was adopted instead of creating a duplicate.", name, adopted.ID),
+		)
+		respData = adopted
+	} else if err != nil {
+		tflog.Error(ctx, err.Error())
+		r.client.addRequestError(&resp.Diagnostics, err)
 		return
-	} else if statusCode != 200 {
-		bodyBytes, _ := ioutil.ReadAll(httpRes.Body)
-		bodyString := string(bodyBytes)
-
-		resp.Diagnostics.AddError("API Error when contacting Gotify instance", fmt.Sprintf("Received a %s response code : %s", strconv.Itoa(statusCode), bodyString))
+	} else if err := checkResponse(httpRes); err != nil {
+		r.client.addResponseError(&resp.Diagnostics, err)
 		return
-	}
-
-	type Response struct {
-		ID       int    `json:"id"`
-		Token    string `json:"token"`
-		Internal bool   `json:"internal"`
-	}
-	var respData Response
-
-	err = json.NewDecoder(httpRes.Body).Decode(&respData)
-	if err != nil {
+	} else if err := r.client.decode(httpRes.Body, &respData); err != nil {
 		resp.Diagnostics.AddError("API Error when contacting Gotify instance", "Failed to decode response body")
 		return
 	}
 
-	data.Id = types.StringValue(strconv.Itoa(respData.ID))
+	data.Id = types.StringValue(strconv.FormatInt(respData.ID, 10))
+	data.ApplicationId = types.Int64Value(respData.ID)
 	data.Token = types.StringValue(respData.Token)
 
 	tflog.Info(ctx, "created a resource")
 
+	// The application is created even when its icon can't be uploaded: the
+	// failure is reported as a warning and image_hash left null, so the next
+	// plan shows the icon as a change and uploads it again, instead of
+	// replacing the tainted application.
+	if image := r.imagePath(data.Image); !image.IsNull() {
+		diags := r.uploadImage(ctx, data.Id.ValueString(), image.ValueString())
+		if diags.HasError() {
+			data.ImageHash = types.StringNull()
+			for _, d := range diags {
+				resp.Diagnostics.AddAttributeWarning(path.Root("image"), "Application image not uploaded", fmt.Sprintf("The application %s was created, but its image couldn't be uploaded: %s: %s. The next apply uploads it again.", name, d.Summary(), d.Detail()))
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(r.setImageHash(&data)...)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -209,22 +481,145 @@ func (r *ApplicationResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	ctx, cancel := r.client.operationContext(ctx, "read", data.Timeouts)
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+	ctx = ownerContext(ctx, data.OwnerUsername, data.OwnerPassword, data.OwnerToken)
+
+	url := strings.Trim(r.client.Config.Url.String(), "\"")
+	id := data.Id.ValueString()
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url+"/application", nil)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		resp.Diagnostics.AddError("Can't send request to Gotify", err.Error())
+		return
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpRes, err := r.client.Do(httpReq)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		r.client.addRequestError(&resp.Diagnostics, err)
+		return
+	}
+	defer httpRes.Body.Close()
+
+	if err := checkResponse(httpRes); err != nil {
+		r.client.addResponseError(&resp.Diagnostics, err)
+		return
+	}
+
+	type JsonReponse []struct {
+		ID              int64   `json:"id"`
+		Token           string  `json:"token"`
+		Name            string  `json:"name"`
+		Description     string  `json:"description"`
+		Internal        bool    `json:"internal"`
+		Image           string  `json:"image"`
+		DefaultPriority int64   `json:"defaultPriority"`
+		LastUsed        *string `json:"lastUsed"`
+	}
+
+	var respData JsonReponse
+
+	err = r.client.decode(httpRes.Body, &respData)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error when contacting Gotify instance", err.Error())
+		return
+	}
+
+	var drifted []string
+
+	for _, application := range respData {
+		if strconv.FormatInt(application.ID, 10) != id {
+			continue
+		}
+
+		priority := NewPriorityValueInt64(application.DefaultPriority)
+
+		// Imported applications have no prior values to drift from.
+		if !data.Name.IsNull() && data.Name.ValueString() != application.Name {
+			drifted = append(drifted, "name")
+		}
+		if !data.Description.IsNull() && normalizeDescription(data.Description.ValueString()) != normalizeDescription(application.Description) {
+			drifted = append(drifted, "description")
+		}
+		if equal, _ := data.Priority.StringSemanticEquals(ctx, priority); !data.Priority.IsNull() && !equal {
+			drifted = append(drifted, "priority")
+		}
+
+		data.Name = types.StringValue(application.Name)
+		data.ApplicationId = types.Int64Value(application.ID)
+		data.Priority = priority
+		data.DefaultPriority = priority
+		if normalizeDescription(data.Description.ValueString()) != normalizeDescription(application.Description) {
+			data.Description = NewDescriptionValue(application.Description)
+		}
+		if data.VerifyToken.ValueBool() && !data.Token.IsNull() && data.Token.ValueString() != application.Token {
+			drifted = append(drifted, "token")
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("token"),
+				"Application token changed",
+				fmt.Sprintf("The token of the application %s no longer matches the state, messages sent with the previous token are refused. Resources using the token must be updated.", application.Name),
+			)
+		}
+		if data.Token.IsNull() || data.VerifyToken.ValueBool() {
+			data.Token = types.StringValue(application.Token)
+		}
+
+		// Imported applications get the hash of their icon, so a
+		// configuration uploading the same file plans no change.
+		if data.ImageHash.IsNull() && application.Image != "" && application.Image != defaultImage {
+			if hash, err := r.client.remoteImageHash(ctx, application.Image); err != nil {
+				tflog.Warn(ctx, fmt.Sprintf("Can't read the icon of application %s: %s", id, err))
+			} else {
+				data.ImageHash = types.StringValue(hash)
+			}
+		}
+
+		if len(drifted) > 0 {
+			resp.Diagnostics.Append(r.client.notifyDrift(ctx, fmt.Sprintf("gotify_application %s (id %s) changed outside of Terraform: %s", application.Name, id, strings.Join(drifted, ", ")))...)
+		}
+
+		tflog.Trace(ctx, "read a resource")
+
+		// Save updated data into Terraform state
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	tflog.Warn(ctx, fmt.Sprintf("Application %s not found, removing it from the state", id))
+	if !data.Name.IsNull() {
+		resp.Diagnostics.Append(r.client.notifyDrift(ctx, fmt.Sprintf("gotify_application %s (id %s) was deleted outside of Terraform", data.Name.ValueString(), id))...)
+	}
+	resp.State.RemoveResource(ctx)
 }
 
 func (r *ApplicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ApplicationResourceModel
+	var data, state ApplicationResourceModel
+
+	resp.Diagnostics.Append(r.client.checkReadOnly("update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	ctx, cancel := r.client.operationContext(ctx, "update", data.Timeouts)
+	defer cancel()
 
-	url := strings.Trim(Config.Url.String(), "\"")
-	token := strings.Trim(Config.Token.String(), "\"")
-	priority, err := strconv.Atoi(strings.Trim(data.Priority.String(), "\""))
+	ctx = moduleContext(ctx, req.ProviderMeta)
+	ctx = ownerContext(ctx, data.OwnerUsername, data.OwnerPassword, data.OwnerToken)
+
+	url := strings.Trim(r.client.Config.Url.String(), "\"")
+	priority, err := data.Priority.Int64()
 	id := strings.Trim(data.Id.String(), "\"")
 
 	if err != nil {
@@ -246,7 +641,7 @@ func (r *ApplicationResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	httpReq, err := http.NewRequest("PUT", fmt.Sprintf("%s/%s/%s", url, "application", id), bytes.NewBuffer(jsonData))
+	httpReq, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/%s/%s", url, "application", id), bytes.NewBuffer(jsonData))
 	if err != nil {
 		tflog.Error(ctx, err.Error())
 		resp.Diagnostics.AddError("Can't send request to Gotify", err.Error())
@@ -254,78 +649,88 @@ func (r *ApplicationResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("X-Gotify-Key", token)
 
 	httpRes, err := r.client.Do(httpReq)
 	if err != nil {
 		tflog.Error(ctx, err.Error())
-		resp.Diagnostics.AddError("API Error when contacting Gotify instance", err.Error())
+		r.client.addRequestError(&resp.Diagnostics, err)
 		return
 	}
 	defer httpRes.Body.Close()
 
-	statusCode := httpRes.StatusCode
-
-	if statusCode == 401 {
-		bodyBytes, _ := ioutil.ReadAll(httpRes.Body)
-		bodyString := string(bodyBytes)
-
-		resp.Diagnostics.AddError("Not Allowed", fmt.Sprintf("Bad token (?) : %s", bodyString))
+	// The new id and token of a recreated application wouldn't match the
+	// plan, so the application is removed from the state and the next plan
+	// creates it instead.
+	if err := checkResponse(httpRes); errors.Is(err, ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		resp.Diagnostics.AddError(
+			"Application disappeared",
+			fmt.Sprintf("The application %s (id %s) was deleted outside of Terraform after the plan was made. Run terraform plan again to recreate it.", data.Name.ValueString(), id),
+		)
 		return
-	} else if statusCode != 200 {
-		bodyBytes, _ := ioutil.ReadAll(httpRes.Body)
-		bodyString := string(bodyBytes)
-
-		resp.Diagnostics.AddError("API Error when contacting Gotify instance", fmt.Sprintf("Received a %s response code : %s", strconv.Itoa(statusCode), bodyString))
+	} else if err != nil {
+		r.client.addResponseError(&resp.Diagnostics, err)
 		return
 	}
 
 	tflog.Info(ctx, "Updated a resource")
 
+	if image := r.imagePath(data.Image); !image.IsNull() && (!data.Image.Equal(state.Image) || !data.ImageHash.Equal(state.ImageHash)) {
+		diags := r.uploadImage(ctx, id, image.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			// Keep the previous image in state so the upload is retried on the next apply.
+			data.Image = state.Image
+			data.ImageHash = state.ImageHash
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.setImageHash(&data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ApplicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data ApplicationResourceModel
 
+	resp.Diagnostics.Append(r.client.checkReadOnly("delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	url := strings.Trim(Config.Url.String(), "\"")
-	token := strings.Trim(Config.Token.String(), "\"")
+	ctx, cancel := r.client.operationContext(ctx, "delete", data.Timeouts)
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+	ctx = ownerContext(ctx, data.OwnerUsername, data.OwnerPassword, data.OwnerToken)
+
+	url := strings.Trim(r.client.Config.Url.String(), "\"")
 	id := strings.Trim(data.Id.String(), "\"")
 
-	httpReq, err := http.NewRequest("DELETE", fmt.Sprintf("%s/%s/%s", url, "application", id), nil)
+	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/%s/%s", url, "application", id), nil)
 	if err != nil {
 		tflog.Error(ctx, err.Error())
 		resp.Diagnostics.AddError("Can't send request to Gotify", err.Error())
 		return
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("X-Gotify-Key", token)
 
 	httpRes, err := r.client.Do(httpReq)
 	if err != nil {
 		tflog.Error(ctx, err.Error())
-		resp.Diagnostics.AddError("API Error when contacting Gotify instance", err.Error())
+		r.client.addRequestError(&resp.Diagnostics, err)
 		return
 	}
 	defer httpRes.Body.Close()
 
-	statusCode := httpRes.StatusCode
-
-	if statusCode == 401 {
-		bodyBytes, _ := ioutil.ReadAll(httpRes.Body)
-		bodyString := string(bodyBytes)
-
-		resp.Diagnostics.AddError("Not Allowed", fmt.Sprintf("Bad token (?) : %s", bodyString))
-		return
-	} else if statusCode != 200 {
-		bodyBytes, _ := ioutil.ReadAll(httpRes.Body)
-		bodyString := string(bodyBytes)
-
-		resp.Diagnostics.AddError("API Error when contacting Gotify instance", fmt.Sprintf("Received a %s response code : %s", strconv.Itoa(statusCode), bodyString))
+	if err := checkResponse(httpRes); err != nil {
+		r.client.addResponseError(&resp.Diagnostics, err)
 		return
 	}
 
@@ -334,6 +739,112 @@ func (r *ApplicationResource) Delete(ctx context.Context, req resource.DeleteReq
 
 }
 
+// imagePath returns the icon of an application configured with image: image
+// itself, or default_application_image_path of the provider when unset.
+func (r *ApplicationResource) imagePath(image types.String) types.String {
+	if image.IsNull() {
+		return r.client.Config.DefaultApplicationImagePath
+	}
+
+	return image
+}
+
+// setImageHash sets the image_hash of data when it was only known once its
+// image was uploaded, e.g. as the path came from another resource.
+func (r *ApplicationResource) setImageHash(data *ApplicationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !data.ImageHash.IsUnknown() {
+		return diags
+	}
+
+	image := r.imagePath(data.Image)
+	if image.IsNull() {
+		data.ImageHash = types.StringNull()
+		return diags
+	}
+
+	hash, err := imageHash(image.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("image"), "Invalid application image", err.Error())
+		return diags
+	}
+	data.ImageHash = types.StringValue(hash)
+
+	return diags
+}
+
+// uploadImage validates the file at imagePath and uploads it as the icon of
+// the application identified by id. Uploads failing on the network or with
+// a server error are retried, up to imageUploadAttempts times.
+func (r *ApplicationResource) uploadImage(ctx context.Context, id string, imagePath string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err := validateImageFile(imagePath); err != nil {
+		diags.AddAttributeError(path.Root("image"), "Invalid application image", err.Error())
+		return diags
+	}
+
+	for attempt := 1; ; attempt++ {
+		var retryable bool
+		diags, retryable = r.uploadImageOnce(ctx, id, imagePath)
+
+		if !diags.HasError() || !retryable || attempt >= imageUploadAttempts {
+			return diags
+		}
+
+		tflog.Warn(ctx, fmt.Sprintf("Uploading image %s to application %s failed, retrying in %s (%d/%d)", imagePath, id, imageUploadRetryInterval, attempt, imageUploadAttempts-1))
+
+		select {
+		case <-ctx.Done():
+			return diags
+		case <-time.After(imageUploadRetryInterval):
+		}
+	}
+}
+
+// uploadImageOnce uploads imagePath as the icon of the application
+// identified by id, and reports whether a failure is worth retrying.
+func (r *ApplicationResource) uploadImageOnce(ctx context.Context, id string, imagePath string) (diag.Diagnostics, bool) {
+	var diags diag.Diagnostics
+
+	url := strings.Trim(r.client.Config.Url.String(), "\"")
+
+	httpReq, err := newImageUploadRequest(ctx, fmt.Sprintf("%s/%s/%s/%s", url, "application", id, "image"), imagePath)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		diags.AddError("Can't send request to Gotify", err.Error())
+		return diags, false
+	}
+
+	// Applications upload their image concurrently, up to the -parallelism of
+	// Terraform, so each upload logs its own progress.
+	tflog.Info(ctx, fmt.Sprintf("Uploading image %s (%d bytes) to application %s", imagePath, httpReq.ContentLength, id))
+	start := time.Now()
+
+	httpRes, err := r.client.Do(httpReq)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		r.client.addRequestError(&diags, err)
+		return diags, true
+	}
+	defer httpRes.Body.Close()
+
+	retryable := httpRes.StatusCode >= http.StatusInternalServerError || httpRes.StatusCode == http.StatusTooManyRequests
+
+	if err := checkResponse(httpRes); errors.Is(err, ErrNotFound) {
+		diags.AddError("Application not found", fmt.Sprintf("Referenced application %s not found, can't upload %s", id, imagePath))
+		return diags, false
+	} else if err != nil {
+		diags.AddError("API Error when uploading application image", fmt.Sprintf("%s (%s)", err, imagePath))
+		return diags, retryable
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Uploaded image %s to application %s in %s", imagePath, id, time.Since(start).Round(time.Millisecond)))
+
+	return diags, false
+}
+
 func (r *ApplicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -4,42 +4,55 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
-func TestAccExampleResource(t *testing.T) {
+func TestAccApplicationResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccExampleResourceConfig("one"),
+				Config: testAccApplicationResourceConfig("7"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("gotify_application.test", "configurable_attribute", "one"),
-					resource.TestCheckResourceAttr("gotify_application.test", "defaulted", "example value when not configured"),
-					resource.TestCheckResourceAttr("gotify_application.test", "id", "example-id"),
+					resource.TestCheckResourceAttr("gotify_application.test", "name", "tf-acc-application"),
+					resource.TestCheckResourceAttr("gotify_application.test", "default_priority", "7"),
+					resource.TestCheckResourceAttr("gotify_application.test", "priority", "7"),
+					resource.TestCheckResourceAttrPair("gotify_application.test", "application_id", "gotify_application.test", "id"),
 				),
 			},
-			// ImportState testing
+			// ImportState testing: every attribute is refreshed from Gotify,
+			// so the imported state matches the created one.
 			{
 				ResourceName:      "gotify_application.test",
 				ImportState:       true,
 				ImportStateVerify: true,
-				// This is not normally necessary, but is here because this
-				// example code does not have an actual upstream service.
-				// Once the Read method is able to refresh information from
-				// the upstream service, this can be removed.
-				ImportStateVerifyIgnore: []string{"configurable_attribute", "defaulted"},
 			},
 			// Update and Read testing
 			{
-				Config: testAccExampleResourceConfig("two"),
+				Config: testAccApplicationResourceConfig("3"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("gotify_application.test", "configurable_attribute", "two"),
+					resource.TestCheckResourceAttr("gotify_application.test", "default_priority", "3"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -47,10 +60,783 @@ func TestAccExampleResource(t *testing.T) {
 	})
 }
 
-func testAccExampleResourceConfig(configurableAttribute string) string {
+func testAccApplicationResourceConfig(priority string) string {
 	return fmt.Sprintf(`
 resource "gotify_application" "test" {
-  configurable_attribute = %[1]q
+  name             = "tf-acc-application"
+  description      = "Created by the acceptance tests"
+  default_priority = %[1]q
 }
-`, configurableAttribute)
+`, priority)
+}
+
+// TestAccApplicationResource_parallel creates and destroys many applications
+// at once, to make sure concurrent operations don't interfere.
+func TestAccApplicationResource_parallel(t *testing.T) {
+	const count = 100
+	start := time.Now()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + fmt.Sprintf(`
+resource "gotify_application" "scale" {
+  count = %d
+  name  = "tf-acc-scale-${count.index}"
+}
+`, count),
+				Check: func(s *terraform.State) error {
+					ids := map[string]bool{}
+					tokens := map[string]bool{}
+
+					for name, rs := range s.RootModule().Resources {
+						if !strings.HasPrefix(name, "gotify_application.scale") {
+							continue
+						}
+
+						ids[rs.Primary.Attributes["id"]] = true
+						tokens[rs.Primary.Attributes["token"]] = true
+					}
+
+					if len(ids) != count || len(tokens) != count {
+						return fmt.Errorf("expected %d distinct ids and tokens, got %d ids and %d tokens", count, len(ids), len(tokens))
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+
+	t.Logf("created and destroyed %d applications in %s", count, time.Since(start))
+}
+
+func TestApplicationResourceModifyPlanDefaults(t *testing.T) {
+	ctx := context.Background()
+	r := NewApplicationResource().(*ApplicationResource)
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	// newState returns the state, plan or config holding model, or a null
+	// one when model is nil.
+	newState := func(model *ApplicationResourceModel) tfsdk.State {
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+		if model != nil {
+			if diags := state.Set(ctx, model); diags.HasError() {
+				t.Fatalf("can't build state: %v", diags)
+			}
+		}
+		return state
+	}
+
+	model := func(description DescriptionValue, priority PriorityValue) *ApplicationResourceModel {
+		return &ApplicationResourceModel{
+			Name:                 types.StringValue("app"),
+			Description:          description,
+			Priority:             priority,
+			Id:                   types.StringUnknown(),
+			Token:                types.StringUnknown(),
+			Image:                types.StringNull(),
+			TokenRotationTrigger: types.MapNull(types.StringType),
+		}
+	}
+
+	defaulted := model(NewDescriptionValue(defaultDescription), NewPriorityValue(defaultPriority))
+
+	tests := map[string]struct {
+		config   *ApplicationResourceModel
+		state    *ApplicationResourceModel
+		warnings int
+	}{
+		"create with defaults": {config: model(DescriptionValue{StringValue: types.StringNull()}, PriorityValue{StringValue: types.StringNull()}), warnings: 2},
+		"create with values":   {config: model(NewDescriptionValue("Alerts"), NewPriorityValue("5"))},
+		"defaults applied":     {config: model(DescriptionValue{StringValue: types.StringNull()}, PriorityValue{StringValue: types.StringNull()}), state: defaulted},
+		"description removed":  {config: model(DescriptionValue{StringValue: types.StringNull()}, NewPriorityValue("5")), state: model(NewDescriptionValue("Alerts"), NewPriorityValue("5")), warnings: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := newState(test.config)
+			req := fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+				Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: newState(defaulted).Raw},
+				State:  newState(test.state),
+			}
+			resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+
+			r.ModifyPlan(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			if resp.Diagnostics.WarningsCount() != test.warnings {
+				t.Fatalf("expected %d warnings, got %v", test.warnings, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestApplicationResourceReadDrift(t *testing.T) {
+	ctx := context.Background()
+
+	fake := newFakeGotify()
+	fake.applications[1] = &fakeApplication{ID: 1, Token: "AManaged", Name: "alerts", Description: "Changed by hand", DefaultPriority: 5}
+	fake.applications[2] = &fakeApplication{ID: 2, Token: "ADrift", Name: "drift"}
+	fake.nextID = 3
+
+	r := &ApplicationResource{
+		client: &GotifyClient{
+			Client: &http.Client{Transport: newTransport(GotifyProviderModel{Mock: types.BoolValue(true)}, nil, "")},
+			Config: GotifyProviderModel{
+				Url:                    NewURLValue(mockUrl),
+				Mock:                   types.BoolValue(true),
+				DriftNotificationToken: types.StringValue("ADrift"),
+			},
+		},
+	}
+	r.client.Transport.(*gotifyTransport).base = &handlerTransport{handler: fake}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	read := func(id string, verifyToken bool) (*fwresource.ReadResponse, ApplicationResourceModel) {
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		diags := state.Set(ctx, &ApplicationResourceModel{
+			Name:                 types.StringValue("alerts"),
+			Description:          NewDescriptionValue("Alerts"),
+			Priority:             NewPriorityValue("5"),
+			Id:                   types.StringValue(id),
+			Token:                types.StringValue("AManaged"),
+			Image:                types.StringNull(),
+			TokenRotationTrigger: types.MapNull(types.StringType),
+			VerifyToken:          types.BoolValue(verifyToken),
+		})
+		if diags.HasError() {
+			t.Fatalf("can't build state: %v", diags)
+		}
+
+		resp := &fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+
+		var data ApplicationResourceModel
+		if !resp.State.Raw.IsNull() {
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		}
+
+		return resp, data
+	}
+
+	_, data := read("1", false)
+	if data.Description.ValueString() != "Changed by hand" || data.Priority.ValueString() != "5" || data.ApplicationId.ValueInt64() != 1 {
+		t.Fatalf("expected the state to be refreshed, got %+v", data)
+	}
+
+	// The token is only checked when verify_token is set.
+	fake.applications[1].Description = "Alerts"
+	fake.applications[1].Token = "ARecreated"
+	if _, data := read("1", false); data.Token.ValueString() != "AManaged" {
+		t.Fatalf("expected the token to be kept, got %s", data.Token.ValueString())
+	}
+
+	resp, data := read("1", true)
+	if data.Token.ValueString() != "ARecreated" || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected the token to be refreshed with a warning, got %s, %v", data.Token.ValueString(), resp.Diagnostics)
+	}
+
+	resp, _ = read("42", false)
+	if !resp.State.Raw.IsNull() {
+		t.Fatal("expected the deleted application to be removed from the state")
+	}
+
+	var notifications []string
+	for _, message := range fake.messages {
+		if message.AppID == 2 && message.Title == driftNotificationTitle {
+			notifications = append(notifications, message.Message)
+		}
+	}
+	sort.Strings(notifications)
+
+	expected := []string{
+		"gotify_application alerts (id 1) changed outside of Terraform: description",
+		"gotify_application alerts (id 1) changed outside of Terraform: token",
+		"gotify_application alerts (id 42) was deleted outside of Terraform",
+	}
+	if !reflect.DeepEqual(notifications, expected) {
+		t.Fatalf("expected drift notifications %v, got %v", expected, notifications)
+	}
+}
+
+func TestApplicationResourceUpdateDisappeared(t *testing.T) {
+	ctx := context.Background()
+
+	r := &ApplicationResource{
+		client: &GotifyClient{
+			Client: &http.Client{Transport: newTransport(GotifyProviderModel{Mock: types.BoolValue(true)}, nil, "")},
+			Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+		},
+	}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := state.Set(ctx, &ApplicationResourceModel{
+		Name:                 types.StringValue("alerts"),
+		Description:          NewDescriptionValue("Alerts"),
+		Priority:             NewPriorityValue("5"),
+		Id:                   types.StringValue("42"),
+		Token:                types.StringValue("AManaged"),
+		Image:                types.StringNull(),
+		TokenRotationTrigger: types.MapNull(types.StringType),
+	})
+	if diags.HasError() {
+		t.Fatalf("can't build state: %v", diags)
+	}
+
+	req := fwresource.UpdateRequest{Plan: tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}, State: state}
+	resp := &fwresource.UpdateResponse{State: state}
+
+	r.Update(ctx, req, resp)
+
+	errs := resp.Diagnostics.Errors()
+	if len(errs) != 1 || errs[0].Summary() != "Application disappeared" {
+		t.Fatalf("expected an application disappeared error, got %v", resp.Diagnostics)
+	}
+
+	// The application is removed from the state, so the next plan creates it.
+	if !resp.State.Raw.IsNull() {
+		t.Fatalf("expected the application to be removed from the state, got %v", resp.State.Raw)
+	}
+}
+
+func TestApplicationResourceModifyPlanDuplicateNames(t *testing.T) {
+	ctx := context.Background()
+	r := &ApplicationResource{client: &GotifyClient{plannedNames: newNameRegistry()}}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := func(name string, ownerUsername types.String) *fwresource.ModifyPlanResponse {
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		diags := state.Set(ctx, &ApplicationResourceModel{
+			Name:                 types.StringValue(name),
+			Description:          NewDescriptionValue("Alerts"),
+			Priority:             NewPriorityValue("5"),
+			Id:                   types.StringUnknown(),
+			Token:                types.StringUnknown(),
+			Image:                types.StringNull(),
+			TokenRotationTrigger: types.MapNull(types.StringType),
+			OwnerUsername:        ownerUsername,
+			OwnerPassword:        types.StringNull(),
+		})
+		if diags.HasError() {
+			t.Fatalf("can't build plan: %v", diags)
+		}
+
+		req := fwresource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+			Plan:   tfsdk.Plan{Schema: state.Schema, Raw: state.Raw},
+			State:  tfsdk.State{Schema: state.Schema, Raw: tftypes.NewValue(state.Schema.Type().TerraformType(ctx), nil)},
+		}
+		resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(ctx, req, resp)
+
+		return resp
+	}
+
+	if resp := plan("alerts", types.StringNull()); resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if resp := plan("alerts", types.StringValue("team-payments")); resp.Diagnostics.HasError() {
+		t.Fatalf("expected applications of another owner to share the name, got %v", resp.Diagnostics)
+	}
+
+	resp := plan("alerts", types.StringNull())
+	if errs := resp.Diagnostics.Errors(); len(errs) != 1 || errs[0].Summary() != "Duplicate application name" {
+		t.Fatalf("expected a duplicate name error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestApplicationResourceModifyPlanReplacement(t *testing.T) {
+	ctx := context.Background()
+	r := &ApplicationResource{client: &GotifyClient{plannedNames: newNameRegistry()}}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	application := func(id types.String, trigger string) tftypes.Value {
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		diags := state.Set(ctx, &ApplicationResourceModel{
+			Name:                 types.StringValue("alerts"),
+			Description:          NewDescriptionValue("Alerts"),
+			Priority:             NewPriorityValue("5"),
+			Id:                   id,
+			Token:                types.StringUnknown(),
+			Image:                types.StringNull(),
+			TokenRotationTrigger: types.MapValueMust(types.StringType, map[string]attr.Value{"rotation": types.StringValue(trigger)}),
+			OwnerUsername:        types.StringNull(),
+			OwnerPassword:        types.StringNull(),
+		})
+		if diags.HasError() {
+			t.Fatalf("can't build application: %v", diags)
+		}
+
+		return state.Raw
+	}
+
+	plan := func(prior tftypes.Value) *fwresource.ModifyPlanResponse {
+		planned := application(types.StringUnknown(), "2")
+		req := fwresource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: planned},
+			Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: planned},
+			State:  tfsdk.State{Schema: schemaResp.Schema, Raw: prior},
+		}
+		resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(ctx, req, resp)
+
+		return resp
+	}
+
+	// Terraform plans the replaced application with its prior state, then
+	// again as a new application.
+	if resp := plan(application(types.StringValue("3"), "1")); resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if resp := plan(tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)); resp.Diagnostics.HasError() {
+		t.Fatalf("expected the replacement to keep the name, got %v", resp.Diagnostics)
+	}
+
+	resp := plan(tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil))
+	if errs := resp.Diagnostics.Errors(); len(errs) != 1 || errs[0].Summary() != "Duplicate application name" {
+		t.Fatalf("expected another new application to be a duplicate, got %v", resp.Diagnostics)
+	}
+}
+
+func TestApplicationResourceUploadImageNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	icon := filepath.Join(t.TempDir(), "icon.png")
+	if err := os.WriteFile(icon, []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The application was deleted, so Gotify answers the upload with a 404.
+	fake := newFakeGotify()
+	var uploads int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/application/7/image" {
+			uploads++
+		}
+		fake.ServeHTTP(w, r)
+	})
+
+	r := &ApplicationResource{
+		client: &GotifyClient{
+			Client: &http.Client{Transport: &handlerTransport{handler: handler}},
+			Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+		},
+	}
+
+	diags := r.uploadImage(ctx, "7", icon)
+
+	errs := diags.Errors()
+	if len(errs) != 1 || errs[0].Summary() != "Application not found" || !strings.Contains(errs[0].Detail(), "Referenced application 7 not found") {
+		t.Fatalf("expected an application not found error, got %v", diags)
+	}
+
+	// A missing application isn't retried.
+	if uploads != 1 {
+		t.Fatalf("expected a single upload, got %d", uploads)
+	}
+}
+
+func TestApplicationResourceCreateDefaultImage(t *testing.T) {
+	ctx := context.Background()
+
+	icon := filepath.Join(t.TempDir(), "icon.png")
+	if err := os.WriteFile(icon, []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := newFakeGotify()
+
+	create := func(defaultImage types.String) *fakeApplication {
+		r := &ApplicationResource{
+			client: &GotifyClient{
+				Client: &http.Client{Transport: newTransport(GotifyProviderModel{Mock: types.BoolValue(true)}, nil, "")},
+				Config: GotifyProviderModel{
+					Url:                         NewURLValue(mockUrl),
+					Mock:                        types.BoolValue(true),
+					DefaultApplicationImagePath: defaultImage,
+				},
+			},
+		}
+		r.client.Transport.(*gotifyTransport).base = &handlerTransport{handler: fake}
+
+		schemaResp := &fwresource.SchemaResponse{}
+		r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		diags := plan.Set(ctx, &ApplicationResourceModel{
+			Name:                 types.StringValue("alerts"),
+			Description:          NewDescriptionValue("Alerts"),
+			Priority:             NewPriorityValue("5"),
+			Id:                   types.StringUnknown(),
+			Token:                types.StringUnknown(),
+			Image:                types.StringNull(),
+			TokenRotationTrigger: types.MapNull(types.StringType),
+		})
+		if diags.HasError() {
+			t.Fatalf("can't build plan: %v", diags)
+		}
+
+		resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+		r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+
+		return fake.applications[fake.nextID-1]
+	}
+
+	if application := create(types.StringNull()); application.Image != defaultImage {
+		t.Fatalf("expected no image to be uploaded, got %s", application.Image)
+	}
+
+	if application := create(types.StringValue(icon)); application.Image == defaultImage {
+		t.Fatal("expected the default image to be uploaded")
+	}
+}
+
+func TestApplicationResourceCreateImageUploadFailure(t *testing.T) {
+	ctx := context.Background()
+
+	interval := imageUploadRetryInterval
+	imageUploadRetryInterval = 0
+	t.Cleanup(func() { imageUploadRetryInterval = interval })
+
+	icon := filepath.Join(t.TempDir(), "icon.png")
+	if err := os.WriteFile(icon, []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		failures     int
+		expectedHash bool
+	}{
+		"retried":    {failures: imageUploadAttempts - 1, expectedHash: true},
+		"given up":   {failures: imageUploadAttempts},
+		"no failure": {expectedHash: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake := newFakeGotify()
+			failures := test.failures
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/image") && failures > 0 {
+					failures--
+					fakeError(w, http.StatusServiceUnavailable, "injected server error")
+					return
+				}
+				fake.ServeHTTP(w, r)
+			})
+
+			r := &ApplicationResource{
+				client: &GotifyClient{
+					Client: &http.Client{Transport: newTransport(GotifyProviderModel{Mock: types.BoolValue(true)}, nil, "")},
+					Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+				},
+			}
+			r.client.Transport.(*gotifyTransport).base = &handlerTransport{handler: handler}
+
+			schemaResp := &fwresource.SchemaResponse{}
+			r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			diags := plan.Set(ctx, &ApplicationResourceModel{
+				Name:                 types.StringValue("alerts"),
+				Description:          NewDescriptionValue("Alerts"),
+				Priority:             NewPriorityValue("5"),
+				Id:                   types.StringUnknown(),
+				Token:                types.StringUnknown(),
+				ApplicationId:        types.Int64Unknown(),
+				Image:                types.StringValue(icon),
+				ImageHash:            types.StringUnknown(),
+				TokenRotationTrigger: types.MapNull(types.StringType),
+			})
+			if diags.HasError() {
+				t.Fatalf("can't build plan: %v", diags)
+			}
+
+			resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+
+			var created ApplicationResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &created)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			// The application is kept either way, with a null image_hash
+			// planning the upload again when it failed.
+			if created.Id.ValueString() != "1" || len(fake.applications) != 1 {
+				t.Fatalf("expected the application to be created, got %+v", created)
+			}
+			if !test.expectedHash && (!created.ImageHash.IsNull() || resp.Diagnostics.WarningsCount() != 1 || fake.applications[1].Image != defaultImage) {
+				t.Fatalf("expected the failed upload to be reported, got %s, %v", created.ImageHash, resp.Diagnostics)
+			}
+			if test.expectedHash && (created.ImageHash.IsNull() || created.ImageHash.IsUnknown() || fake.applications[1].Image == defaultImage) {
+				t.Fatalf("expected the image to be uploaded, got %s, %v", created.ImageHash, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestApplicationResourceCreateLostAnswer(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		status      int
+		created     bool
+		onlyAlerts  bool
+		wantID      string
+		wantError   bool
+		wantAdopted bool
+		wantLists   int
+	}{
+		"created":     {status: http.StatusGatewayTimeout, created: true, wantID: "3", wantAdopted: true, wantLists: 2},
+		"not created": {status: http.StatusGatewayTimeout, created: false, wantError: true, wantLists: 2},
+		"answered":    {status: http.StatusOK, created: true, wantID: "3", wantLists: 1},
+		"refused":     {status: http.StatusBadRequest, created: false, wantError: true, wantLists: 1},
+		// The identical application is the latest one, but existed before.
+		"not created, identical latest": {status: http.StatusGatewayTimeout, created: false, onlyAlerts: true, wantError: true, wantLists: 2},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// An identical application exists, created before another one.
+			fake := newFakeGotify()
+			fake.applications[1] = &fakeApplication{ID: 1, Token: "AUnmanaged", Name: "alerts", Description: "Alerts", DefaultPriority: 5}
+			fake.applications[2] = &fakeApplication{ID: 2, Token: "ABackups", Name: "backups", Description: "Backups", DefaultPriority: 2}
+			fake.nextID = 3
+			if test.onlyAlerts {
+				delete(fake.applications, 2)
+			}
+
+			// The reverse proxy may give up on the creation, which Gotify may
+			// still complete.
+			var lists int
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet && r.URL.Path == "/application" {
+					lists++
+				}
+				if r.Method == http.MethodPost && r.URL.Path == "/application" && test.status != http.StatusOK {
+					if test.created {
+						fake.ServeHTTP(httptest.NewRecorder(), r)
+					}
+					w.WriteHeader(test.status)
+					return
+				}
+				fake.ServeHTTP(w, r)
+			})
+
+			r := &ApplicationResource{
+				client: &GotifyClient{
+					Client: &http.Client{Transport: newTransport(GotifyProviderModel{Mock: types.BoolValue(true)}, nil, "")},
+					Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+				},
+			}
+			r.client.Transport.(*gotifyTransport).base = &handlerTransport{handler: handler}
+
+			schemaResp := &fwresource.SchemaResponse{}
+			r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			diags := plan.Set(ctx, &ApplicationResourceModel{
+				Name:                 types.StringValue("alerts"),
+				Description:          NewDescriptionValue("Alerts"),
+				Priority:             NewPriorityValue("5"),
+				Id:                   types.StringUnknown(),
+				Token:                types.StringUnknown(),
+				Image:                types.StringNull(),
+				TokenRotationTrigger: types.MapNull(types.StringType),
+			})
+			if diags.HasError() {
+				t.Fatalf("can't build plan: %v", diags)
+			}
+
+			resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+
+			// Applications are listed again once the answer is lost.
+			if lists != test.wantLists {
+				t.Fatalf("expected %d lists of the applications, got %d", test.wantLists, lists)
+			}
+
+			if test.wantError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected the creation to fail")
+				}
+				return
+			}
+
+			wantWarnings := 0
+			if test.wantAdopted {
+				wantWarnings = 1
+			}
+
+			if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != wantWarnings {
+				t.Fatalf("expected %d warnings, got %v", wantWarnings, resp.Diagnostics)
+			}
+
+			var data ApplicationResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if data.Id.ValueString() != test.wantID || data.ApplicationId.ValueInt64() != 3 || data.Token.ValueString() != fake.applications[3].Token {
+				t.Fatalf("expected the application %s to be adopted, got %+v", test.wantID, data)
+			}
+
+			if len(fake.applications) != 3 {
+				t.Fatalf("expected no duplicate application, got %d applications", len(fake.applications))
+			}
+		})
+	}
+}
+
+func TestApplicationResourceModifyPlanImageHash(t *testing.T) {
+	ctx := context.Background()
+
+	icon := filepath.Join(t.TempDir(), "icon.png")
+	if err := os.WriteFile(icon, []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	uploaded, err := imageHash(icon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The icon is replaced in place, at the same path.
+	if err := os.WriteFile(icon, []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x10"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &ApplicationResource{client: &GotifyClient{Config: GotifyProviderModel{Url: NewURLValue(mockUrl)}}}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := state.Set(ctx, &ApplicationResourceModel{
+		Name:                 types.StringValue("alerts"),
+		Description:          NewDescriptionValue("Alerts"),
+		Priority:             NewPriorityValue("5"),
+		Id:                   types.StringValue("1"),
+		Token:                types.StringValue("AManaged"),
+		Image:                types.StringValue(icon),
+		ImageHash:            types.StringValue(uploaded),
+		TokenRotationTrigger: types.MapNull(types.StringType),
+	})
+	if diags.HasError() {
+		t.Fatalf("can't build state: %v", diags)
+	}
+
+	req := fwresource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+		Plan:   tfsdk.Plan{Schema: state.Schema, Raw: state.Raw},
+		State:  state,
+	}
+	resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+
+	r.ModifyPlan(ctx, req, resp)
+
+	var planned types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("image_hash"), &planned)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if planned.ValueString() == uploaded || !strings.HasSuffix(planned.ValueString(), "(20 bytes)") {
+		t.Fatalf("expected the plan to show the new content of the icon, got %s", planned)
+	}
+}
+
+func TestApplicationResourceImportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	icon := filepath.Join(t.TempDir(), "icon.png")
+	if err := os.WriteFile(icon, []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := newFakeGotify()
+
+	r := &ApplicationResource{
+		client: &GotifyClient{
+			Client: &http.Client{Transport: newTransport(GotifyProviderModel{Mock: types.BoolValue(true)}, nil, "")},
+			Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+		},
+	}
+	r.client.Transport.(*gotifyTransport).base = &handlerTransport{handler: fake}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	diags := plan.Set(ctx, &ApplicationResourceModel{
+		Name:                 types.StringValue("alerts"),
+		Description:          NewDescriptionValue("Alerts"),
+		Priority:             NewPriorityValue("7"),
+		DefaultPriority:      NewPriorityValue("7"),
+		Id:                   types.StringUnknown(),
+		Token:                types.StringUnknown(),
+		ApplicationId:        types.Int64Unknown(),
+		Image:                types.StringValue(icon),
+		ImageHash:            types.StringUnknown(),
+		TokenRotationTrigger: types.MapNull(types.StringType),
+	})
+	if diags.HasError() {
+		t.Fatalf("can't build plan: %v", diags)
+	}
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+
+	var created ApplicationResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(ctx, &created)...)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", createResp.Diagnostics)
+	}
+
+	importResp := &fwresource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: created.Id.ValueString()}, importResp)
+
+	readResp := &fwresource.ReadResponse{State: importResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: importResp.State}, readResp)
+
+	var imported ApplicationResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &imported)...)
+	if importResp.Diagnostics.HasError() || readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v %v", importResp.Diagnostics, readResp.Diagnostics)
+	}
+
+	// The path of the icon can't be read back from Gotify, only its content.
+	imported.Image = created.Image
+
+	if !reflect.DeepEqual(imported, created) {
+		t.Fatalf("expected the imported state to match the created one\ncreated:  %+v\nimported: %+v", created, imported)
+	}
 }
@@ -0,0 +1,285 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ApplicationsDataSource{}
+
+func NewApplicationsDataSource() datasource.DataSource {
+	return &ApplicationsDataSource{}
+}
+
+// ApplicationsDataSource defines the data source implementation.
+type ApplicationsDataSource struct {
+	client *GotifyClient
+}
+
+// ApplicationsDataSourceModel describes the data source data model.
+type ApplicationsDataSourceModel struct {
+	Id                  types.String       `tfsdk:"id"`
+	SortBy              types.String       `tfsdk:"sort_by"`
+	SortOrder           types.String       `tfsdk:"sort_order"`
+	NamePrefix          types.String       `tfsdk:"name_prefix"`
+	DescriptionContains types.String       `tfsdk:"description_contains"`
+	OwnerUsername       types.String       `tfsdk:"owner_username"`
+	OwnerPassword       types.String       `tfsdk:"owner_password"`
+	OwnerToken          types.String       `tfsdk:"owner_token"`
+	Offset              types.Int64        `tfsdk:"offset"`
+	Limit               types.Int64        `tfsdk:"limit"`
+	TotalCount          types.Int64        `tfsdk:"total_count"`
+	Applications        []ApplicationModel `tfsdk:"applications"`
+}
+
+// ApplicationModel describes an application listed by the data source.
+type ApplicationModel struct {
+	Id            types.String  `tfsdk:"id"`
+	ApplicationId types.Int64   `tfsdk:"application_id"`
+	Name          types.String  `tfsdk:"name"`
+	Description   types.String  `tfsdk:"description"`
+	Priority      PriorityValue `tfsdk:"priority"`
+	Token         types.String  `tfsdk:"token"`
+	PushUrl       types.String  `tfsdk:"push_url"`
+	LastUsed      types.String  `tfsdk:"last_used"`
+}
+
+func (d *ApplicationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_applications"
+}
+
+func (d *ApplicationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Applications data source",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Placeholder identifier",
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the applications whose name starts with this prefix",
+			},
+			"description_contains": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the applications whose description contains this string, e.g. an ownership tag such as `team:payments`",
+			},
+			"owner_username": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the Gotify user whose applications are listed, authenticating with `owner_username` and `owner_password` instead of the provider credentials",
+			},
+			"owner_password": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Password of the user set in `owner_username`",
+			},
+			"owner_token": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Client token of the Gotify user whose applications are listed, instead of `owner_username` and `owner_password`",
+			},
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Field the applications are sorted by: `id`, `name` or `last_used`. Defaults to `id`",
+			},
+			"sort_order": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Order the applications are sorted in: `asc` or `desc`. Defaults to `asc`",
+			},
+			"offset": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of matching applications skipped, once sorted. Defaults to `0`",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of applications listed, after `offset`. All of them are listed by default",
+			},
+			"total_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of applications matching the filters, regardless of `offset` and `limit`",
+			},
+			"applications": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Applications of the Gotify user",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Application identifier",
+						},
+						"application_id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Application identifier, as a number",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the application",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Description of the application",
+						},
+						"priority": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Default priority of the messages sent by the application",
+							CustomType:          PriorityType{},
+						},
+						"token": schema.StringAttribute{
+							Computed:            true,
+							Sensitive:           true,
+							MarkdownDescription: "Token of the application",
+						},
+						"push_url": schema.StringAttribute{
+							Computed:            true,
+							Sensitive:           true,
+							MarkdownDescription: "URL messages of the application can be pushed to, including its token",
+						},
+						"last_used": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Date the application was last used at, empty if it never was",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ApplicationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*GotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *GotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ApplicationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ApplicationsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	resp.Diagnostics.Append(validateSort(data.SortBy, data.SortOrder)...)
+	resp.Diagnostics.Append(validatePage(data.Offset, data.Limit)...)
+	resp.Diagnostics.Append(validateOwnerCredentials(data.OwnerUsername, data.OwnerPassword, data.OwnerToken)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := d.client.operationContext(ctx, "read", nil)
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	ctx = ownerContext(ctx, data.OwnerUsername, data.OwnerPassword, data.OwnerToken)
+
+	url := strings.Trim(d.client.Config.Url.String(), "\"")
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url+"/application", nil)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		resp.Diagnostics.AddError("Can't send request to Gotify", err.Error())
+		return
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpRes, err := d.client.Do(httpReq)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		d.client.addRequestError(&resp.Diagnostics, err)
+		return
+	}
+	defer httpRes.Body.Close()
+
+	if err := checkResponse(httpRes); err != nil {
+		d.client.addResponseError(&resp.Diagnostics, err)
+		return
+	}
+
+	type JsonReponse []struct {
+		DefaultPriority int64  `json:"defaultPriority"`
+		Description     string `json:"description"`
+		ID              int64  `json:"id"`
+		Image           string `json:"image"`
+		Internal        bool   `json:"internal"`
+		Name            string `json:"name"`
+		Token           string `json:"token"`
+		LastUsed        string `json:"lastUsed"`
+	}
+
+	var respData JsonReponse
+
+	err = d.client.decode(httpRes.Body, &respData)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error when contacting Gotify instance", err.Error())
+		return
+	}
+
+	data.Applications = []ApplicationModel{}
+	for _, Application := range respData {
+		if !strings.HasPrefix(Application.Name, data.NamePrefix.ValueString()) {
+			continue
+		}
+
+		if !strings.Contains(Application.Description, data.DescriptionContains.ValueString()) {
+			continue
+		}
+
+		data.Applications = append(data.Applications, ApplicationModel{
+			Id:            types.StringValue(strconv.FormatInt(Application.ID, 10)),
+			ApplicationId: types.Int64Value(Application.ID),
+			Name:          types.StringValue(Application.Name),
+			Description:   types.StringValue(Application.Description),
+			Priority:      NewPriorityValueInt64(Application.DefaultPriority),
+			Token:         types.StringValue(Application.Token),
+			PushUrl:       types.StringValue(pushUrl(url, Application.Token)),
+			LastUsed:      types.StringValue(Application.LastUsed),
+		})
+	}
+
+	sortEntries(data.Applications, func(application ApplicationModel) sortKey {
+		id, _ := strconv.ParseInt(application.Id.ValueString(), 10, 64)
+		return sortKey{id: id, name: application.Name.ValueString(), lastUsed: application.LastUsed.ValueString()}
+	}, data.SortBy, data.SortOrder)
+
+	data.TotalCount = types.Int64Value(int64(len(data.Applications)))
+	data.Applications = pageEntries(data.Applications, data.Offset, data.Limit)
+
+	data.Id = types.StringValue("applications")
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// pushUrl returns the URL messages are pushed to with the application token.
+func pushUrl(url string, token string) string {
+	return fmt.Sprintf("%s/message?token=%s", strings.TrimSuffix(url, "/"), token)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccApplicationsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccProviderConfig() + testAccApplicationsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gotify_applications.test", "applications.#", "1"),
+					resource.TestCheckResourceAttr("data.gotify_applications.test", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.gotify_applications.test", "applications.0.name", "tf-acc-applications-test"),
+					resource.TestCheckResourceAttrPair("data.gotify_applications.test", "applications.0.application_id", "data.gotify_applications.test", "applications.0.id"),
+					resource.TestMatchResourceAttr("data.gotify_applications.test", "applications.0.push_url", regexp.MustCompile(`/message\?token=A`)),
+				),
+			},
+		},
+	})
+}
+
+func TestPushUrl(t *testing.T) {
+	for _, url := range []string{"https://gotify.example.com", "https://gotify.example.com/"} {
+		if got := pushUrl(url, "AToken"); got != "https://gotify.example.com/message?token=AToken" {
+			t.Fatalf("unexpected push url %s for %s", got, url)
+		}
+	}
+}
+
+const testAccApplicationsDataSourceConfig = `
+resource "gotify_application" "test" {
+  name        = "tf-acc-applications-test"
+  description = "Owned by team:payments"
+}
+
+resource "gotify_application" "other" {
+  name        = "tf-acc-applications-other"
+  description = "Owned by team:search"
+}
+
+data "gotify_applications" "test" {
+  name_prefix          = "tf-acc-applications-"
+  description_contains = "team:payments"
+
+  depends_on = [gotify_application.test, gotify_application.other]
+}
+`
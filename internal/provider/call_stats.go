@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// callStats counts the calls sent to Gotify by the provider process.
+var callStats = &apiCallStats{}
+
+// apiCallStats holds the number and cumulative duration of the calls sent to
+// Gotify, per operation.
+type apiCallStats struct {
+	mu         sync.Mutex
+	operations map[string]*operationStats
+}
+
+// operationStats are the statistics of one operation.
+type operationStats struct {
+	count    int64
+	duration time.Duration
+}
+
+// record adds a call to operation which took duration.
+func (s *apiCallStats) record(operation string, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.operations == nil {
+		s.operations = map[string]*operationStats{}
+	}
+
+	stats, ok := s.operations[operation]
+	if !ok {
+		stats = &operationStats{}
+		s.operations[operation] = stats
+	}
+
+	stats.count++
+	stats.duration += duration
+}
+
+// summary returns a single line describing the recorded calls, sorted by
+// operation, or an empty string when no call was sent.
+func (s *apiCallStats) summary() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.operations) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(s.operations))
+	for name := range s.operations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var total operationStats
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		stats := s.operations[name]
+		total.count += stats.count
+		total.duration += stats.duration
+
+		parts = append(parts, fmt.Sprintf("%q: calls=%d duration_ms=%d", name, stats.count, stats.duration.Milliseconds()))
+	}
+
+	return fmt.Sprintf("gotify API call summary: calls=%d duration_ms=%d operations={%s}", total.count, total.duration.Milliseconds(), strings.Join(parts, ", "))
+}
+
+// CallSummary returns a single line describing the calls sent to Gotify by
+// the provider process, or an empty string when none was sent.
+func CallSummary() string {
+	return callStats.summary()
+}
+
+// callOperation returns the operation of req: its method and path, with
+// identifiers replaced by {id} so calls on different objects are grouped.
+func callOperation(req *http.Request, basePath string) string {
+	segments := strings.Split(strings.TrimPrefix(req.URL.Path, basePath), "/")
+	for i, segment := range segments {
+		if segment != "" && strings.Trim(segment, "0123456789") == "" {
+			segments[i] = "{id}"
+		}
+	}
+
+	return fmt.Sprintf("%s %s", req.Method, strings.Join(segments, "/"))
+}
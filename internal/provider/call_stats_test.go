@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCallOperation(t *testing.T) {
+	tests := map[string]struct {
+		method   string
+		url      string
+		basePath string
+		expected string
+	}{
+		"list":     {method: http.MethodGet, url: "https://gotify.example.com/application", expected: "GET /application"},
+		"id":       {method: http.MethodPut, url: "https://gotify.example.com/application/42", expected: "PUT /application/{id}"},
+		"image":    {method: http.MethodPost, url: "https://gotify.example.com/application/42/image", expected: "POST /application/{id}/image"},
+		"query":    {method: http.MethodGet, url: "https://gotify.example.com/message?limit=200&since=12", expected: "GET /message"},
+		"sub path": {method: http.MethodDelete, url: "https://example.com/gotify/application/7", basePath: "/gotify", expected: "DELETE /application/{id}"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, test.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := callOperation(req, test.basePath); got != test.expected {
+				t.Fatalf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestCallStatsSummary(t *testing.T) {
+	stats := &apiCallStats{}
+
+	if summary := stats.summary(); summary != "" {
+		t.Fatalf("expected no summary without calls, got %q", summary)
+	}
+
+	stats.record("PUT /application/{id}", 30*time.Millisecond)
+	stats.record("GET /application", 10*time.Millisecond)
+	stats.record("GET /application", 20*time.Millisecond)
+
+	expected := `gotify API call summary: calls=3 duration_ms=60 operations={"GET /application": calls=2 duration_ms=30, "PUT /application/{id}": calls=1 duration_ms=30}`
+	if summary := stats.summary(); summary != expected {
+		t.Fatalf("expected %q, got %q", expected, summary)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// clientNamePrefix starts the names of the clients created by Terraform, so
+// name_prefix filters can select them.
+const clientNamePrefix = "tf"
+
+// nameSeparators matches the runs of characters replaced by a dash in the
+// parts of a client name.
+var nameSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// clientName returns the conventional name of a client, such as
+// tf-prod-alertmanager. The default workspace is left out of the name, and
+// every part is lowercased with other characters than letters and digits
+// replaced by dashes.
+func clientName(workspace string, environment string, purpose string) (string, error) {
+	workspace = normalizeNamePart(workspace)
+	if workspace == "default" {
+		workspace = ""
+	}
+
+	purpose = normalizeNamePart(purpose)
+	if purpose == "" {
+		return "", errors.New("purpose must contain at least one letter or digit")
+	}
+
+	parts := []string{clientNamePrefix}
+	for _, part := range []string{workspace, normalizeNamePart(environment), purpose} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, "-"), nil
+}
+
+// normalizeNamePart lowercases part and replaces the characters other than
+// letters and digits by dashes.
+func normalizeNamePart(part string) string {
+	return strings.Trim(nameSeparators.ReplaceAllString(strings.ToLower(part), "-"), "-")
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ClientNameFunction{}
+
+func NewClientNameFunction() function.Function {
+	return &ClientNameFunction{}
+}
+
+// ClientNameFunction defines the function implementation.
+type ClientNameFunction struct{}
+
+func (f *ClientNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "client_name"
+}
+
+func (f *ClientNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Conventional name of a client",
+		MarkdownDescription: "Builds the name of a client from the Terraform workspace, the environment and the purpose of the client, e.g. `tf-prod-alertmanager`. The `default` workspace and empty parts are left out, and every part is lowercased with other characters than letters and digits replaced by dashes.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "workspace",
+				MarkdownDescription: "Terraform workspace, usually `terraform.workspace`",
+			},
+			function.StringParameter{
+				Name:                "environment",
+				MarkdownDescription: "Environment of the client, e.g. `prod`",
+			},
+			function.StringParameter{
+				Name:                "purpose",
+				MarkdownDescription: "What the client is used for, e.g. `alertmanager`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ClientNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var workspace, environment, purpose string
+
+	resp.Diagnostics.Append(req.Arguments.Get(ctx, &workspace, &environment, &purpose)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, err := clientName(workspace, environment, purpose)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid client name", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, name)...)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestClientNameFunction(t *testing.T) {
+	tests := map[string]struct {
+		workspace   string
+		environment string
+		purpose     string
+		expected    string
+		wantErr     bool
+	}{
+		"default workspace": {workspace: "default", environment: "prod", purpose: "alertmanager", expected: "tf-prod-alertmanager"},
+		"workspace":         {workspace: "eu-west", environment: "prod", purpose: "alertmanager", expected: "tf-eu-west-prod-alertmanager"},
+		"normalized":        {workspace: "default", environment: "Prod", purpose: "Alert Manager!", expected: "tf-prod-alert-manager"},
+		"no environment":    {workspace: "default", purpose: "backups", expected: "tf-backups"},
+		"empty purpose":     {workspace: "default", environment: "prod", purpose: " - ", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(test.workspace),
+					types.StringValue(test.environment),
+					types.StringValue(test.purpose),
+				}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewClientNameFunction().Run(context.Background(), req, &resp)
+
+			if test.wantErr {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !resp.Result.Equal(function.NewResultData(types.StringValue(test.expected))) {
+				t.Fatalf("expected %q, got %s", test.expected, resp.Result.Value())
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ClientsDataSource{}
+
+func NewClientsDataSource() datasource.DataSource {
+	return &ClientsDataSource{}
+}
+
+// ClientsDataSource defines the data source implementation.
+type ClientsDataSource struct {
+	client *GotifyClient
+}
+
+// ClientsDataSourceModel describes the data source data model.
+type ClientsDataSourceModel struct {
+	Id          types.String  `tfsdk:"id"`
+	NamePrefix  types.String  `tfsdk:"name_prefix"`
+	ExcludeSelf types.Bool    `tfsdk:"exclude_self"`
+	SortBy      types.String  `tfsdk:"sort_by"`
+	SortOrder   types.String  `tfsdk:"sort_order"`
+	Clients     []ClientModel `tfsdk:"clients"`
+}
+
+// ClientModel describes a client listed by the data source.
+type ClientModel struct {
+	Id       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Token    types.String `tfsdk:"token"`
+	LastUsed types.String `tfsdk:"last_used"`
+}
+
+func (d *ClientsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clients"
+}
+
+func (d *ClientsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Clients data source",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Placeholder identifier",
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the clients whose name starts with this prefix",
+			},
+			"exclude_self": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave out the client whose token the provider authenticates with. Defaults to `false`",
+			},
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Field the clients are sorted by: `id`, `name` or `last_used`. Defaults to `id`",
+			},
+			"sort_order": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Order the clients are sorted in: `asc` or `desc`. Defaults to `asc`",
+			},
+			"clients": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Clients of the Gotify user",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Client identifier",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the client",
+						},
+						"token": schema.StringAttribute{
+							Computed:            true,
+							Sensitive:           true,
+							MarkdownDescription: "Token of the client",
+						},
+						"last_used": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Date the client was last used at, empty if it never was",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ClientsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*GotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *GotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ClientsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClientsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	resp.Diagnostics.Append(validateSort(data.SortBy, data.SortOrder)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := d.client.operationContext(ctx, "read", nil)
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	url := strings.Trim(d.client.Config.Url.String(), "\"")
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url+"/client", nil)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		resp.Diagnostics.AddError("Can't send request to Gotify", err.Error())
+		return
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpRes, err := d.client.Do(httpReq)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		d.client.addRequestError(&resp.Diagnostics, err)
+		return
+	}
+	defer httpRes.Body.Close()
+
+	if err := checkResponse(httpRes); err != nil {
+		d.client.addResponseError(&resp.Diagnostics, err)
+		return
+	}
+
+	type JsonReponse []struct {
+		ID       int64  `json:"id"`
+		Name     string `json:"name"`
+		Token    string `json:"token"`
+		LastUsed string `json:"lastUsed"`
+	}
+
+	var respData JsonReponse
+
+	err = d.client.decode(httpRes.Body, &respData)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error when contacting Gotify instance", err.Error())
+		return
+	}
+
+	data.Clients = []ClientModel{}
+	for _, Client := range respData {
+		if !strings.HasPrefix(Client.Name, data.NamePrefix.ValueString()) {
+			continue
+		}
+
+		if data.ExcludeSelf.ValueBool() && Client.Token == d.client.Config.Token.ValueString() {
+			tflog.Debug(ctx, fmt.Sprintf("Excluding client %d used by the provider", Client.ID))
+			continue
+		}
+
+		data.Clients = append(data.Clients, ClientModel{
+			Id:       types.StringValue(strconv.FormatInt(Client.ID, 10)),
+			Name:     types.StringValue(Client.Name),
+			Token:    types.StringValue(Client.Token),
+			LastUsed: types.StringValue(Client.LastUsed),
+		})
+	}
+
+	sortEntries(data.Clients, func(client ClientModel) sortKey {
+		id, _ := strconv.ParseInt(client.Id.ValueString(), 10, 64)
+		return sortKey{id: id, name: client.Name.ValueString(), lastUsed: client.LastUsed.ValueString()}
+	}, data.SortBy, data.SortOrder)
+
+	data.Id = types.StringValue("clients")
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
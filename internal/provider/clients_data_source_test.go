@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccClientsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccProviderConfig() + testAccClientsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gotify_clients.test", "clients.#", "0"),
+				),
+			},
+			// The client of the provider is left out
+			{
+				Config: testAccProviderConfig() + testAccClientsDataSourceExcludeSelfConfig,
+				Check:  testAccCheckClientsExcludeToken("data.gotify_clients.test", os.Getenv("GOTIFY_TOKEN")),
+			},
+		},
+	})
+}
+
+func testAccCheckClientsExcludeToken(name string, token string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found", name)
+		}
+
+		for key, value := range rs.Primary.Attributes {
+			if value == token {
+				return fmt.Errorf("%s lists the client of the provider as %s", name, key)
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccClientsDataSourceConfig = `
+data "gotify_clients" "test" {
+  name_prefix = "tf-acc-no-such-client-"
+}
+`
+
+const testAccClientsDataSourceExcludeSelfConfig = `
+data "gotify_clients" "test" {
+  exclude_self = true
+}
+`
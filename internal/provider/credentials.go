@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// credentials authenticate calls to Gotify, either with a client token or
+// with the username and password of a user.
+type credentials struct {
+	token    string
+	username string
+	password string
+}
+
+// credentialsKey is the context key of the credentials overriding the
+// provider ones.
+type credentialsKey struct{}
+
+// withCredentials returns a copy of ctx whose calls to Gotify authenticate
+// with creds instead of the provider credentials, e.g. to manage the
+// applications of another user.
+func withCredentials(ctx context.Context, creds credentials) context.Context {
+	return context.WithValue(ctx, credentialsKey{}, creds)
+}
+
+// credentialsFromContext returns the credentials set on ctx by
+// withCredentials, if any.
+func credentialsFromContext(ctx context.Context) (credentials, bool) {
+	creds, ok := ctx.Value(credentialsKey{}).(credentials)
+	return creds, ok
+}
+
+// ownerContext returns ctx authenticating calls as the user set by the
+// owner_username, owner_password and owner_token attributes, when set.
+func ownerContext(ctx context.Context, username, password, token types.String) context.Context {
+	if username.IsNull() && token.IsNull() {
+		return ctx
+	}
+
+	return withCredentials(ctx, credentials{
+		token:    token.ValueString(),
+		username: username.ValueString(),
+		password: password.ValueString(),
+	})
+}
+
+// validateOwnerCredentials returns an error diagnostic unless the owner
+// attributes set either a token or both a username and a password.
+func validateOwnerCredentials(username, password, token types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if username.IsNull() != password.IsNull() {
+		diags.AddAttributeError(path.Root("owner_password"), "Incomplete owner credentials", "Both owner_username and owner_password must be set to authenticate as another user")
+	}
+
+	if !username.IsNull() && !token.IsNull() {
+		diags.AddAttributeError(path.Root("owner_token"), "Conflicting owner credentials", "Only one of owner_token and owner_username can be set")
+	}
+
+	return diags
+}
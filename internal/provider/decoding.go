@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// supportedMajorVersion is the major version of the Gotify API the response
+// types of the provider describe.
+const supportedMajorVersion = "2"
+
+// decode decodes the JSON body of a Gotify response into v. With
+// strict_decoding, fields v doesn't describe are errors, to surface skew
+// between the provider and the server.
+func (c *GotifyClient) decode(body io.Reader, v interface{}) error {
+	decoder := json.NewDecoder(body)
+	if c.Config.StrictDecoding.ValueBool() {
+		decoder.DisallowUnknownFields()
+	}
+
+	if err := decoder.Decode(v); err != nil {
+		if c.Config.StrictDecoding.ValueBool() && strings.HasPrefix(err.Error(), "json: unknown field") {
+			return fmt.Errorf("%w, the Gotify server answered with a field the provider doesn't know (strict_decoding is set)", err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// serverVersion returns the version of the Gotify server, e.g. 2.4.0.
+func (c *GotifyClient) serverVersion(ctx context.Context) (string, error) {
+	url := strings.Trim(c.Config.Url.String(), "\"")
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url+"/version", nil)
+	if err != nil {
+		return "", err
+	}
+
+	httpRes, err := c.Do(httpReq)
+	if err != nil {
+		return "", err
+	}
+	defer httpRes.Body.Close()
+
+	if err := checkResponse(httpRes); err != nil {
+		return "", err
+	}
+
+	var respData struct {
+		Version   string `json:"version"`
+		Commit    string `json:"commit"`
+		BuildDate string `json:"buildDate"`
+	}
+
+	if err := c.decode(httpRes.Body, &respData); err != nil {
+		return "", err
+	}
+
+	return respData.Version, nil
+}
+
+// checkStrictVersion returns an error unless version is a version of Gotify
+// the response types of the provider describe.
+func checkStrictVersion(version string) error {
+	if strings.Split(strings.TrimPrefix(version, "v"), ".")[0] != supportedMajorVersion {
+		return fmt.Errorf("the Gotify server runs version %q, the provider understands the responses of Gotify %s.x (strict_decoding is set)", version, supportedMajorVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestGotifyClientDecode(t *testing.T) {
+	const body = `{"health":"green","database":"green","cluster":"green"}`
+
+	for strict, wantErr := range map[bool]bool{false: false, true: true} {
+		client := &GotifyClient{Config: GotifyProviderModel{StrictDecoding: types.BoolValue(strict)}}
+
+		var health struct {
+			Health   string `json:"health"`
+			Database string `json:"database"`
+		}
+		err := client.decode(strings.NewReader(body), &health)
+
+		if wantErr && (err == nil || !strings.Contains(err.Error(), `unknown field "cluster"`)) {
+			t.Fatalf("expected an unknown field error in strict mode, got %v", err)
+		}
+
+		if !wantErr && (err != nil || health.Health != "green") {
+			t.Fatalf("unexpected result %+v, %v", health, err)
+		}
+	}
+}
+
+func TestCheckStrictVersion(t *testing.T) {
+	tests := map[string]bool{
+		"2.4.0":  false,
+		"v2.0.1": false,
+		"3.0.0":  true,
+		"1.2.1":  true,
+		"":       true,
+	}
+
+	for version, wantErr := range tests {
+		if err := checkStrictVersion(version); (err != nil) != wantErr {
+			t.Fatalf("unexpected result for version %q: %v", version, err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// renamedAttribute describes a string attribute renamed from from to to,
+// whose values are of type T, e.g. types.String. The old name stays accepted
+// until the next major version: both are Optional and Computed, the old one
+// carries deprecationMessage, and ModifyPlan resolves them to the same value
+// so either can be referenced.
+type renamedAttribute[T basetypes.StringValuable] struct {
+	from string
+	to   string
+}
+
+// deprecationMessage is the DeprecationMessage of the old attribute, which
+// Terraform shows as a warning when it is configured.
+func (a renamedAttribute[T]) deprecationMessage() string {
+	return fmt.Sprintf("Use %s instead, %s will be removed in the next major version", a.to, a.from)
+}
+
+// validate returns an error when both names are set in config.
+func (a renamedAttribute[T]) validate(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var from, to T
+
+	diags.Append(config.GetAttribute(ctx, path.Root(a.from), &from)...)
+	diags.Append(config.GetAttribute(ctx, path.Root(a.to), &to)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if !from.IsNull() && !to.IsNull() {
+		diags.AddAttributeError(path.Root(a.from), "Conflicting attributes", fmt.Sprintf("%s is the deprecated name of %s, only set %s", a.from, a.to, a.to))
+	}
+
+	return diags
+}
+
+// configured returns the value set in config under either name, null when
+// neither is set.
+func (a renamedAttribute[T]) configured(ctx context.Context, config tfsdk.Config) (T, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var from, to T
+
+	diags.Append(config.GetAttribute(ctx, path.Root(a.from), &from)...)
+	diags.Append(config.GetAttribute(ctx, path.Root(a.to), &to)...)
+
+	if !to.IsNull() {
+		return to, diags
+	}
+
+	return from, diags
+}
+
+// resolve plans both names with the value set in config under either of
+// them, or fallback when neither is set.
+func (a renamedAttribute[T]) resolve(ctx context.Context, config tfsdk.Config, plan *tfsdk.Plan, fallback T) diag.Diagnostics {
+	value, diags := a.configured(ctx, config)
+	if diags.HasError() {
+		return diags
+	}
+
+	if value.IsNull() {
+		value = fallback
+	}
+
+	diags.Append(plan.SetAttribute(ctx, path.Root(a.from), value)...)
+	diags.Append(plan.SetAttribute(ctx, path.Root(a.to), value)...)
+
+	return diags
+}
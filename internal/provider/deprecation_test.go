@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRenamedAttribute(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	NewApplicationResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	tests := map[string]struct {
+		from     PriorityValue
+		to       PriorityValue
+		expected string
+		wantErr  bool
+	}{
+		"new name": {from: PriorityValue{StringValue: types.StringNull()}, to: NewPriorityValue("5"), expected: "5"},
+		"old name": {from: NewPriorityValue("5"), to: PriorityValue{StringValue: types.StringNull()}, expected: "5"},
+		"neither":  {from: PriorityValue{StringValue: types.StringNull()}, to: PriorityValue{StringValue: types.StringNull()}, expected: "1"},
+		"both":     {from: NewPriorityValue("5"), to: NewPriorityValue("7"), wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			diags := state.Set(ctx, &ApplicationResourceModel{
+				Name:                 types.StringValue("alerts"),
+				Description:          NewDescriptionValue("Alerts"),
+				Priority:             test.from,
+				DefaultPriority:      test.to,
+				Id:                   types.StringUnknown(),
+				Token:                types.StringUnknown(),
+				Image:                types.StringNull(),
+				TokenRotationTrigger: types.MapNull(types.StringType),
+			})
+			if diags.HasError() {
+				t.Fatalf("can't build config: %v", diags)
+			}
+			config := tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+
+			if diags := priorityRename.validate(ctx, config); diags.HasError() != test.wantErr {
+				t.Fatalf("unexpected validation result: %v", diags)
+			}
+			if test.wantErr {
+				return
+			}
+
+			plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+			if diags := priorityRename.resolve(ctx, config, &plan, NewPriorityValue(defaultPriority)); diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+
+			var data ApplicationResourceModel
+			if diags := plan.Get(ctx, &data); diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+
+			if data.Priority.ValueString() != test.expected || data.DefaultPriority.ValueString() != test.expected {
+				t.Fatalf("expected both attributes to be planned with %s, got %s and %s", test.expected, data.Priority, data.DefaultPriority)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ basetypes.StringTypable = DescriptionType{}
+var _ basetypes.StringValuableWithSemanticEquals = DescriptionValue{}
+
+// DescriptionType is a string type for descriptions, whose values are equal
+// when they only differ by trailing whitespace or line endings, as is common
+// with descriptions written as heredocs.
+type DescriptionType struct {
+	basetypes.StringType
+}
+
+func (t DescriptionType) Equal(o attr.Type) bool {
+	other, ok := o.(DescriptionType)
+
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t DescriptionType) String() string {
+	return "DescriptionType"
+}
+
+func (t DescriptionType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return DescriptionValue{StringValue: in}, nil
+}
+
+func (t DescriptionType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return DescriptionValue{StringValue: stringValue}, nil
+}
+
+func (t DescriptionType) ValueType(ctx context.Context) attr.Value {
+	return DescriptionValue{}
+}
+
+// DescriptionValue is a value of DescriptionType.
+type DescriptionValue struct {
+	basetypes.StringValue
+}
+
+// NewDescriptionValue returns a known DescriptionValue holding value.
+func NewDescriptionValue(value string) DescriptionValue {
+	return DescriptionValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v DescriptionValue) Equal(o attr.Value) bool {
+	other, ok := o.(DescriptionValue)
+
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v DescriptionValue) Type(ctx context.Context) attr.Type {
+	return DescriptionType{}
+}
+
+func (v DescriptionValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(DescriptionValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+
+		return false, diags
+	}
+
+	return normalizeDescription(v.ValueString()) == normalizeDescription(newValue.ValueString()), diags
+}
+
+// normalizeDescription converts line endings to \n and removes whitespace at
+// the end of every line and of the description.
+func normalizeDescription(description string) string {
+	lines := strings.Split(strings.ReplaceAll(description, "\r\n", "\n"), "\n")
+
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDescriptionValueSemanticEquals(t *testing.T) {
+	tests := map[string]struct {
+		current  string
+		new      string
+		expected bool
+	}{
+		"identical":           {current: "my app", new: "my app", expected: true},
+		"trailing newline":    {current: "my app\n", new: "my app", expected: true},
+		"trailing spaces":     {current: "line one  \nline two\t\n", new: "line one\nline two", expected: true},
+		"windows line ending": {current: "line one\r\nline two\r\n", new: "line one\nline two", expected: true},
+		"leading whitespace":  {current: "  my app", new: "my app", expected: false},
+		"different content":   {current: "my app", new: "my other app", expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := NewDescriptionValue(test.current).StringSemanticEquals(context.Background(), NewDescriptionValue(test.new))
+
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if equal != test.expected {
+				t.Fatalf("expected %t, got %t", test.expected, equal)
+			}
+		})
+	}
+}
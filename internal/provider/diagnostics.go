@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"syscall"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// gotifyError is the body Gotify answers failed requests with.
+type gotifyError struct {
+	Error            string `json:"error"`
+	ErrorCode        int    `json:"errorCode"`
+	ErrorDescription string `json:"errorDescription"`
+}
+
+// Errors wrapped by the errors checkResponse returns, to tell the failures
+// callers handle apart with errors.Is.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrConflict     = errors.New("conflict")
+)
+
+// responseError describes a request Gotify answered with an error. It wraps
+// the error matching the status code, if any.
+type responseError struct {
+	detail string
+	kind   error
+}
+
+func (e *responseError) Error() string {
+	return e.detail
+}
+
+func (e *responseError) Unwrap() error {
+	return e.kind
+}
+
+// checkResponse returns an error describing the request answered by
+// httpRes unless it succeeded: its method, path, status code and the error
+// returned by Gotify. The body of failed responses is consumed.
+func checkResponse(httpRes *http.Response) error {
+	if httpRes.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	err := &responseError{detail: responseErrorDetail(httpRes)}
+
+	switch httpRes.StatusCode {
+	case http.StatusNotFound:
+		err.kind = ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		err.kind = ErrUnauthorized
+	case http.StatusConflict:
+		err.kind = ErrConflict
+	}
+
+	return err
+}
+
+// addResponseError adds an error to diags describing the failed response
+// err returned by checkResponse.
+func (c *GotifyClient) addResponseError(diags *diag.Diagnostics, err error) {
+	summary := "API Error when contacting Gotify instance"
+	if errors.Is(err, ErrUnauthorized) {
+		summary = "Not Allowed"
+	}
+
+	c.addFailure(diags, summary, err.Error(), "")
+}
+
+// tokenCandidate matches the runs of characters Gotify tokens are made of.
+var tokenCandidate = regexp.MustCompile(`[A-Za-z0-9._-]+`)
+
+// redactTokens masks the Gotify tokens in s, e.g. echoed in a response body
+// or in the query of a URL, so diagnostics never leak credentials into CI
+// logs. Gotify tokens are an A (application), C (client) or P (plugin)
+// followed by 14 characters. Words of the same shape are masked as well.
+func redactTokens(s string) string {
+	return tokenCandidate.ReplaceAllStringFunc(s, func(candidate string) string {
+		if len(candidate) != 15 || !strings.ContainsRune("ACP", rune(candidate[0])) {
+			return candidate
+		}
+		return "REDACTED"
+	})
+}
+
+// redactedError is an error whose message is redacted by redactTokens.
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return redactTokens(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// responseErrorDetail describes the failed request answered by httpRes. The
+// tokens the body may echo are redacted.
+func responseErrorDetail(httpRes *http.Response) string {
+	request := "Request"
+	if httpRes.Request != nil {
+		request = fmt.Sprintf("%s %s", httpRes.Request.Method, httpRes.Request.URL.Path)
+	}
+
+	detail := fmt.Sprintf("%s returned %s", request, httpRes.Status)
+
+	bodyBytes, _ := io.ReadAll(httpRes.Body)
+
+	var apiError gotifyError
+	if err := json.Unmarshal(bodyBytes, &apiError); err == nil && apiError.ErrorDescription != "" {
+		return fmt.Sprintf("%s: %s", detail, redactTokens(apiError.ErrorDescription))
+	}
+
+	if body := strings.TrimSpace(string(bodyBytes)); body != "" {
+		return fmt.Sprintf("%s: %s", detail, redactTokens(body))
+	}
+
+	return detail
+}
+
+// addRequestError adds an error to diags describing why a request couldn't
+// be sent to Gotify, with a hint to fix the most common network failures.
+func (c *GotifyClient) addRequestError(diags *diag.Diagnostics, err error) {
+	summary, hint := classifyRequestError(err)
+	c.addFailure(diags, summary, err.Error(), hint)
+}
+
+// classifyRequestError returns the diagnostic summary and remediation hint
+// matching the network failure err.
+func classifyRequestError(err error) (string, string) {
+	var dnsErr *net.DNSError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certificateErr x509.CertificateInvalidError
+	var recordHeaderErr tls.RecordHeaderError
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, errMissingCredentials):
+		return "Missing credentials", "Set token, or username and password, in the provider configuration or in the GOTIFY_TOKEN, GOTIFY_USERNAME and GOTIFY_PASSWORD environment variables. Only the gotify_health and gotify_version data sources can be used without credentials."
+	case errors.As(err, &dnsErr):
+		return "Can't resolve Gotify host", fmt.Sprintf("The host %s couldn't be resolved. Check the url of the provider and that its host is resolvable from the machine running Terraform.", dnsErr.Name)
+	case errors.As(err, &unknownAuthorityErr):
+		return "TLS handshake with Gotify failed", "The certificate of the Gotify instance is signed by an unknown authority. Add the authority to the system trust store, or point SSL_CERT_FILE to it."
+	case errors.As(err, &hostnameErr):
+		return "TLS handshake with Gotify failed", "The certificate of the Gotify instance isn't valid for the host of the url. Use the host the certificate was issued for, or set tls_server_name."
+	case errors.As(err, &certificateErr):
+		return "TLS handshake with Gotify failed", "The certificate of the Gotify instance is invalid, check it isn't expired."
+	case errors.As(err, &recordHeaderErr):
+		return "TLS handshake with Gotify failed", "The Gotify instance didn't answer with TLS. Check whether the url should use http instead of https."
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return "Connection to Gotify refused", "Nothing listens on the host and port of the url. Check Gotify is running and the url is reachable from the machine running Terraform."
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "Timeout contacting Gotify", "Gotify didn't answer in time. Check the url is reachable from the machine running Terraform and that no firewall drops the traffic, or raise the timeouts."
+	}
+
+	return "Can't contact Gotify Instance", ""
+}
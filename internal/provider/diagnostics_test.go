@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestResponseErrorDetail(t *testing.T) {
+	tests := map[string]struct {
+		status   int
+		body     string
+		expected string
+	}{
+		"gotify error": {
+			status:   404,
+			body:     `{"error":"Not Found","errorCode":404,"errorDescription":"app with id 42 doesn't exists"}`,
+			expected: "DELETE /application/42 returned 404 Not Found: app with id 42 doesn't exists",
+		},
+		"plain body": {
+			status:   502,
+			body:     "Bad Gateway\n",
+			expected: "DELETE /application/42 returned 502 Bad Gateway: Bad Gateway",
+		},
+		"empty body": {
+			status:   500,
+			expected: "DELETE /application/42 returned 500 Internal Server Error",
+		},
+		"token in body": {
+			status:   400,
+			body:     `{"error":"Bad Request","errorCode":400,"errorDescription":"application AbCdEf.G-h_1234 already exists"}`,
+			expected: "DELETE /application/42 returned 400 Bad Request: application REDACTED already exists",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			httpRes := &http.Response{
+				StatusCode: test.status,
+				Status:     fmt.Sprintf("%d %s", test.status, http.StatusText(test.status)),
+				Body:       io.NopCloser(strings.NewReader(test.body)),
+				Request: &http.Request{
+					Method: http.MethodDelete,
+					URL:    &url.URL{Scheme: "https", Host: "gotify.example.com", Path: "/application/42"},
+				},
+			}
+			if got := responseErrorDetail(httpRes); got != test.expected {
+				t.Fatalf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestRedactTokens(t *testing.T) {
+	tests := map[string]string{
+		`GET /message?token=AbCdEf.G-h_1234 failed`:    `GET /message?token=REDACTED failed`,
+		`{"token":"CbCdEfGh1234567"}`:                  `{"token":"REDACTED"}`,
+		`PbCdEfGh1234567 and AbCdEfGh1234567`:          `REDACTED and REDACTED`,
+		`app with id 42 doesn't exists`:                `app with id 42 doesn't exists`,
+		`AbCdEfGh12345678 is longer than a token`:      `AbCdEfGh12345678 is longer than a token`,
+		`https://gotify.example.com/application/image`: `https://gotify.example.com/application/image`,
+	}
+
+	for s, expected := range tests {
+		if got := redactTokens(s); got != expected {
+			t.Errorf("redactTokens(%q) = %q, expected %q", s, got, expected)
+		}
+	}
+}
+
+func TestCheckResponse(t *testing.T) {
+	tests := map[int]error{
+		http.StatusOK:                  nil,
+		http.StatusNotFound:            ErrNotFound,
+		http.StatusUnauthorized:        ErrUnauthorized,
+		http.StatusForbidden:           ErrUnauthorized,
+		http.StatusConflict:            ErrConflict,
+		http.StatusInternalServerError: nil,
+	}
+
+	for status, expected := range tests {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			err := checkResponse(&http.Response{
+				StatusCode: status,
+				Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+				Body:       io.NopCloser(strings.NewReader("")),
+			})
+
+			if status == http.StatusOK {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), fmt.Sprint(status)) {
+				t.Fatalf("expected an error describing the %d status, got %v", status, err)
+			}
+
+			for _, kind := range []error{ErrNotFound, ErrUnauthorized, ErrConflict} {
+				if errors.Is(err, kind) != (kind == expected) {
+					t.Fatalf("unexpected errors.Is(%v, %v) = %t", err, kind, errors.Is(err, kind))
+				}
+			}
+		})
+	}
+}
+
+func TestClassifyRequestError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected string
+	}{
+		"dns": {
+			err:      &url.Error{Op: "Get", URL: "https://gotify.invalid", Err: &net.DNSError{Name: "gotify.invalid", Err: "no such host"}},
+			expected: "Can't resolve Gotify host",
+		},
+		"unknown authority": {
+			err:      &url.Error{Op: "Get", URL: "https://gotify.example.com", Err: x509.UnknownAuthorityError{}},
+			expected: "TLS handshake with Gotify failed",
+		},
+		"connection refused": {
+			err:      &url.Error{Op: "Get", URL: "http://localhost:1", Err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}},
+			expected: "Connection to Gotify refused",
+		},
+		"redacted": {
+			err:      &redactedError{err: &url.Error{Op: "Get", URL: "http://localhost:1/message?token=AbCdEf.G-h_1234", Err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}}},
+			expected: "Connection to Gotify refused",
+		},
+		"timeout": {
+			err:      &url.Error{Op: "Get", URL: "http://gotify.example.com", Err: os.ErrDeadlineExceeded},
+			expected: "Timeout contacting Gotify",
+		},
+		"other": {
+			err:      fmt.Errorf("refusing POST request, the provider is configured with read_only = true"),
+			expected: "Can't contact Gotify Instance",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			summary, _ := classifyRequestError(test.err)
+			if summary != test.expected {
+				t.Fatalf("expected %q, got %q", test.expected, summary)
+			}
+		})
+	}
+}
+
+func TestClassifyRequestErrorTLS(t *testing.T) {
+	// The test server certificate isn't trusted by the default client.
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	_, err := http.Get(server.URL)
+	if err == nil {
+		t.Fatal("expected the TLS handshake to fail")
+	}
+
+	if summary, hint := classifyRequestError(err); summary != "TLS handshake with Gotify failed" || hint == "" {
+		t.Fatalf("unexpected classification %q: %q", summary, hint)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// driftNotificationTitle is the title of the messages reporting drift.
+const driftNotificationTitle = "Terraform drift detected"
+
+// notifyDrift sends description as a message with the application token set
+// in drift_notification_token, when set. Failing to notify doesn't fail the
+// refresh, so it only returns warnings.
+func (c *GotifyClient) notifyDrift(ctx context.Context, description string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tflog.Warn(ctx, description)
+
+	token := c.Config.DriftNotificationToken.ValueString()
+	if token == "" {
+		return diags
+	}
+
+	if c.Config.ReadOnly.ValueBool() {
+		tflog.Info(ctx, "Not sending the drift notification, the provider is configured with read_only = true")
+		return diags
+	}
+
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"title":   driftNotificationTitle,
+		"message": description,
+	})
+	if err != nil {
+		diags.AddWarning("Can't send drift notification", err.Error())
+		return diags
+	}
+
+	url := strings.Trim(c.Config.Url.String(), "\"")
+
+	// The message is sent with the application token instead of the
+	// credentials managing the resource.
+	httpReq, err := http.NewRequestWithContext(withCredentials(ctx, credentials{token: token}), "POST", url+"/message", bytes.NewBuffer(jsonData))
+	if err != nil {
+		diags.AddWarning("Can't send drift notification", err.Error())
+		return diags
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpRes, err := c.Do(httpReq)
+	if err != nil {
+		diags.AddWarning("Can't send drift notification", err.Error())
+		return diags
+	}
+	defer httpRes.Body.Close()
+
+	if err := checkResponse(httpRes); err != nil {
+		diags.AddWarning("Can't send drift notification", fmt.Sprintf("%s, check drift_notification_token is the token of an application", err))
+	}
+
+	return diags
+}
@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// environmentPrefix prefixes the environment variables provider attributes
+// fall back to, named after the attribute in upper case.
+const environmentPrefix = "GOTIFY_"
+
+// applyEnvironment sets the attributes of data which aren't configured from
+// their environment variable, e.g. token from GOTIFY_TOKEN.
+func applyEnvironment(data *GotifyProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Credentials are read from the environment as a whole: a configured
+	// token isn't overridden by basic auth from GOTIFY_USERNAME, which the
+	// transport would prefer.
+	if data.Token.IsNull() && data.Username.IsNull() && data.Password.IsNull() {
+		envString(&data.Token, "token")
+		envString(&data.Username, "username")
+		envString(&data.Password, "password")
+	}
+
+	envString(&data.TlsServerName, "tls_server_name")
+	envString(&data.HostHeader, "host_header")
+	envString(&data.UserAgentExtra, "user_agent_extra")
+	envString(&data.AuthorizationBearer, "authorization_bearer")
+	envString(&data.DataSourceCacheTtl, "data_source_cache_ttl")
+	envString(&data.DriftNotificationToken, "drift_notification_token")
+	envString(&data.DefaultApplicationImagePath, "default_application_image_path")
+
+	diags.Append(envBool(&data.FollowRedirects, "follow_redirects")...)
+	diags.Append(envBool(&data.TokenInQuery, "token_in_query")...)
+	diags.Append(envBool(&data.ProxyFromEnvironment, "proxy_from_environment")...)
+	diags.Append(envBool(&data.ReadOnly, "read_only")...)
+	diags.Append(envBool(&data.LogOnly, "log_only")...)
+	diags.Append(envBool(&data.Mock, "mock")...)
+	diags.Append(envBool(&data.StrictDecoding, "strict_decoding")...)
+	diags.Append(envInt64(&data.MaxResponseSize, "max_response_size")...)
+
+	// url and urls conflict, so neither is read from the environment when
+	// one of them is configured.
+	if data.Url.IsNull() && data.Urls.IsNull() {
+		if value, ok := lookupEnv("url"); ok {
+			diags.Append(envURLError("url", value)...)
+			data.Url = NewURLValue(value)
+		} else if value, ok := lookupEnv("urls"); ok {
+			elements := []attr.Value{}
+			for _, element := range strings.Split(value, ",") {
+				element = strings.TrimSpace(element)
+				diags.Append(envURLError("urls", element)...)
+				elements = append(elements, NewURLValue(element))
+			}
+
+			var listDiags diag.Diagnostics
+			data.Urls, listDiags = types.ListValue(URLType{}, elements)
+			diags.Append(listDiags...)
+		}
+	}
+
+	if data.DefaultTimeouts == nil {
+		data.DefaultTimeouts = &TimeoutsModel{
+			Create:  types.StringNull(),
+			Read:    types.StringNull(),
+			Update:  types.StringNull(),
+			Delete:  types.StringNull(),
+			Request: types.StringNull(),
+			Upload:  types.StringNull(),
+		}
+	}
+	envString(&data.DefaultTimeouts.Create, "default_timeouts_create")
+	envString(&data.DefaultTimeouts.Read, "default_timeouts_read")
+	envString(&data.DefaultTimeouts.Update, "default_timeouts_update")
+	envString(&data.DefaultTimeouts.Delete, "default_timeouts_delete")
+	envString(&data.DefaultTimeouts.Request, "default_timeouts_request")
+	envString(&data.DefaultTimeouts.Upload, "default_timeouts_upload")
+
+	return diags
+}
+
+// lookupEnv returns the value of the environment variable of attribute,
+// ignoring empty values.
+func lookupEnv(attribute string) (string, bool) {
+	value := os.Getenv(environmentVariable(attribute))
+
+	return value, value != ""
+}
+
+// environmentVariable returns the name of the environment variable of
+// attribute.
+func environmentVariable(attribute string) string {
+	return environmentPrefix + strings.ToUpper(attribute)
+}
+
+func envString(value *types.String, attribute string) {
+	if env, ok := lookupEnv(attribute); ok && value.IsNull() {
+		*value = types.StringValue(env)
+	}
+}
+
+func envBool(value *types.Bool, attribute string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	env, ok := lookupEnv(attribute)
+	if !ok || !value.IsNull() {
+		return diags
+	}
+
+	parsed, err := strconv.ParseBool(env)
+	if err != nil {
+		diags.AddError("Invalid environment variable", fmt.Sprintf("%s must be true or false, got %q", environmentVariable(attribute), env))
+		return diags
+	}
+
+	*value = types.BoolValue(parsed)
+
+	return diags
+}
+
+func envInt64(value *types.Int64, attribute string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	env, ok := lookupEnv(attribute)
+	if !ok || !value.IsNull() {
+		return diags
+	}
+
+	parsed, err := strconv.ParseInt(env, 10, 64)
+	if err != nil {
+		diags.AddError("Invalid environment variable", fmt.Sprintf("%s must be a number, got %q", environmentVariable(attribute), env))
+		return diags
+	}
+
+	*value = types.Int64Value(parsed)
+
+	return diags
+}
+
+func envURLError(attribute string, value string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err := validateURL(value); err != nil {
+		diags.AddError("Invalid environment variable", fmt.Sprintf("%s: %s", environmentVariable(attribute), err))
+	}
+
+	return diags
+}
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// nullProviderModel returns a model with every attribute unset.
+func nullProviderModel() GotifyProviderModel {
+	return GotifyProviderModel{
+		Token:                       types.StringNull(),
+		Url:                         URLValue{StringValue: types.StringNull()},
+		FollowRedirects:             types.BoolNull(),
+		TlsServerName:               types.StringNull(),
+		HostHeader:                  types.StringNull(),
+		Username:                    types.StringNull(),
+		Password:                    types.StringNull(),
+		TokenInQuery:                types.BoolNull(),
+		AuthorizationBearer:         types.StringNull(),
+		DriftNotificationToken:      types.StringNull(),
+		DefaultApplicationImagePath: types.StringNull(),
+		Urls:                        types.ListNull(URLType{}),
+		ProxyFromEnvironment:        types.BoolNull(),
+		ReadOnly:                    types.BoolNull(),
+		LogOnly:                     types.BoolNull(),
+		Mock:                        types.BoolNull(),
+		StrictDecoding:              types.BoolNull(),
+		MaxResponseSize:             types.Int64Null(),
+		UserAgentExtra:              types.StringNull(),
+		DataSourceCacheTtl:          types.StringNull(),
+	}
+}
+
+func TestApplyEnvironment(t *testing.T) {
+	t.Setenv("GOTIFY_TOKEN", "CToken")
+	t.Setenv("GOTIFY_URLS", "https://gotify-a.example.com, https://gotify-b.example.com")
+	t.Setenv("GOTIFY_READ_ONLY", "true")
+	t.Setenv("GOTIFY_MAX_RESPONSE_SIZE", "1024")
+	t.Setenv("GOTIFY_DEFAULT_TIMEOUTS_READ", "30s")
+	t.Setenv("GOTIFY_USER_AGENT_EXTRA", "pipeline/42")
+
+	data := nullProviderModel()
+	data.ReadOnly = types.BoolValue(false)
+
+	if diags := applyEnvironment(&data); diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+
+	if data.Token.ValueString() != "CToken" || data.UserAgentExtra.ValueString() != "pipeline/42" {
+		t.Fatalf("expected string attributes to be read from the environment, got %s and %s", data.Token, data.UserAgentExtra)
+	}
+
+	if data.ReadOnly.ValueBool() {
+		t.Fatal("expected the configured read_only to take precedence over the environment")
+	}
+
+	if data.MaxResponseSize.ValueInt64() != 1024 || data.DefaultTimeouts.Read.ValueString() != "30s" {
+		t.Fatalf("unexpected max_response_size %s or read timeout %s", data.MaxResponseSize, data.DefaultTimeouts.Read)
+	}
+
+	var urls []string
+	if diags := data.Urls.ElementsAs(context.Background(), &urls, false); diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+
+	if expected := []string{"https://gotify-a.example.com", "https://gotify-b.example.com"}; !reflect.DeepEqual(urls, expected) {
+		t.Fatalf("expected urls %v, got %v", expected, urls)
+	}
+
+	if !data.Url.IsNull() {
+		t.Fatalf("expected url to be left unset when urls is read, got %s", data.Url)
+	}
+}
+
+func TestApplyEnvironmentCredentials(t *testing.T) {
+	t.Setenv("GOTIFY_TOKEN", "CToken")
+	t.Setenv("GOTIFY_USERNAME", "admin")
+	t.Setenv("GOTIFY_PASSWORD", "secret")
+
+	// A configured token isn't mixed with basic auth from the environment.
+	data := nullProviderModel()
+	data.Token = types.StringValue("CConfigured")
+
+	if diags := applyEnvironment(&data); diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+
+	if data.Token.ValueString() != "CConfigured" || !data.Username.IsNull() || !data.Password.IsNull() {
+		t.Fatalf("expected only the configured token, got token %s, username %s and password %s", data.Token, data.Username, data.Password)
+	}
+
+	// Without configured credentials, they are all read from the environment.
+	data = nullProviderModel()
+
+	if diags := applyEnvironment(&data); diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+
+	if data.Token.ValueString() != "CToken" || data.Username.ValueString() != "admin" || data.Password.ValueString() != "secret" {
+		t.Fatalf("expected the credentials to be read from the environment, got token %s, username %s and password %s", data.Token, data.Username, data.Password)
+	}
+}
+
+func TestApplyEnvironmentInvalid(t *testing.T) {
+	tests := map[string]string{
+		"GOTIFY_MOCK":              "yes please",
+		"GOTIFY_MAX_RESPONSE_SIZE": "1MiB",
+		"GOTIFY_URL":               "gotify.example.com",
+	}
+
+	for env, value := range tests {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv(env, value)
+
+			data := nullProviderModel()
+			if diags := applyEnvironment(&data); !diags.HasError() {
+				t.Fatalf("expected %s=%q to be refused", env, value)
+			}
+		})
+	}
+}
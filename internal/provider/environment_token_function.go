@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// environmentToken returns the token of tokens for workspace. The
+// environments are matched exactly first, then once normalized like the
+// parts of a client name, so Prod and prod select the same token.
+func environmentToken(tokens map[string]string, workspace string) (string, error) {
+	if token, ok := tokens[workspace]; ok {
+		return token, nil
+	}
+
+	environments := make([]string, 0, len(tokens))
+	for environment := range tokens {
+		environments = append(environments, environment)
+	}
+	sort.Strings(environments)
+
+	var matches []string
+	for _, environment := range environments {
+		if normalizeNamePart(environment) == normalizeNamePart(workspace) {
+			matches = append(matches, environment)
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return tokens[matches[0]], nil
+	case 0:
+		return "", fmt.Errorf("no token for workspace %q, the environments are: %s", workspace, strings.Join(environments, ", "))
+	default:
+		return "", fmt.Errorf("workspace %q matches several environments: %s", workspace, strings.Join(matches, ", "))
+	}
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &EnvironmentTokenFunction{}
+
+func NewEnvironmentTokenFunction() function.Function {
+	return &EnvironmentTokenFunction{}
+}
+
+// EnvironmentTokenFunction defines the function implementation.
+type EnvironmentTokenFunction struct{}
+
+func (f *EnvironmentTokenFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "environment_token"
+}
+
+func (f *EnvironmentTokenFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Application token of the current environment",
+		MarkdownDescription: "Selects the application token of the environment named like the Terraform workspace from a map of environment to token, instead of a `lookup()` repeated in every module. Environments are matched exactly, then case-insensitively with other characters than letters and digits treated as dashes. An unknown workspace is an error listing the environments.",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:                "tokens",
+				MarkdownDescription: "Application tokens by environment, e.g. `{ prod = gotify_application.prod.token }`",
+				ElementType:         types.StringType,
+			},
+			function.StringParameter{
+				Name:                "workspace",
+				MarkdownDescription: "Terraform workspace, usually `terraform.workspace`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *EnvironmentTokenFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tokens map[string]string
+	var workspace string
+
+	resp.Diagnostics.Append(req.Arguments.Get(ctx, &tokens, &workspace)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := environmentToken(tokens, workspace)
+	if err != nil {
+		resp.Diagnostics.AddError("Unknown environment", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, token)...)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEnvironmentTokenFunction(t *testing.T) {
+	tokens := map[string]string{
+		"prod":    "Aprod",
+		"staging": "Astaging",
+		"eu-west": "Aeuwest",
+	}
+
+	tests := map[string]struct {
+		tokens    map[string]string
+		workspace string
+		expected  string
+		wantErr   bool
+	}{
+		"exact":      {tokens: tokens, workspace: "prod", expected: "Aprod"},
+		"normalized": {tokens: tokens, workspace: "EU_West", expected: "Aeuwest"},
+		"unknown":    {tokens: tokens, workspace: "dev", wantErr: true},
+		"ambiguous":  {tokens: map[string]string{"Prod": "A1", "prod ": "A2"}, workspace: "PROD", wantErr: true},
+		"empty":      {tokens: map[string]string{}, workspace: "prod", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			elements := map[string]attr.Value{}
+			for environment, token := range test.tokens {
+				elements[environment] = types.StringValue(token)
+			}
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.MapValueMust(types.StringType, elements),
+					types.StringValue(test.workspace),
+				}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewEnvironmentTokenFunction().Run(context.Background(), req, &resp)
+
+			if test.wantErr {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !resp.Result.Equal(function.NewResultData(types.StringValue(test.expected))) {
+				t.Fatalf("expected %q, got %s", test.expected, resp.Result.Value())
+			}
+		})
+	}
+}
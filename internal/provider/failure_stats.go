@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// failureStormThreshold is the number of failed calls to Gotify described in
+// full during a run. Once it is exceeded, a single warning sums up the
+// failures per category and the following errors drop their remediation
+// hint, so an apply failing on every resource stays readable.
+const failureStormThreshold = 5
+
+// callFailureStats holds the number of failed calls to Gotify, per
+// diagnostic summary.
+type callFailureStats struct {
+	mu         sync.Mutex
+	total      int
+	categories map[string]int
+}
+
+// record adds a failure of category, returning the number of failures
+// recorded so far.
+func (s *callFailureStats) record(category string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.categories == nil {
+		s.categories = map[string]int{}
+	}
+
+	s.categories[category]++
+	s.total++
+
+	return s.total
+}
+
+// summary describes the recorded failures, the most frequent categories
+// first.
+func (s *callFailureStats) summary() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	categories := make([]string, 0, len(s.categories))
+	for category := range s.categories {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if s.categories[categories[i]] != s.categories[categories[j]] {
+			return s.categories[categories[i]] > s.categories[categories[j]]
+		}
+		return categories[i] < categories[j]
+	})
+
+	parts := make([]string, 0, len(categories))
+	for _, category := range categories {
+		parts = append(parts, fmt.Sprintf("%d %q", s.categories[category], category))
+	}
+
+	return fmt.Sprintf("%d calls to Gotify failed so far: %s", s.total, strings.Join(parts, ", "))
+}
+
+// addFailure adds an error to diags describing a failed call to Gotify.
+// The failure is counted by the client, and the one exceeding
+// failureStormThreshold comes with the count of the failures per category.
+func (c *GotifyClient) addFailure(diags *diag.Diagnostics, summary string, detail string, hint string) {
+	total := 0
+	if c != nil && c.failures != nil {
+		total = c.failures.record(summary)
+	}
+
+	if total > failureStormThreshold || hint == "" {
+		diags.AddError(summary, detail)
+	} else {
+		diags.AddError(summary, fmt.Sprintf("%s\n\n%s", detail, hint))
+	}
+
+	if total == failureStormThreshold+1 {
+		diags.AddWarning("Repeated failures contacting Gotify", fmt.Sprintf("%s. Only the first %d failures are described in full.", c.failures.summary(), failureStormThreshold))
+	}
+}
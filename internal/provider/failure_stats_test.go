@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestAddFailure(t *testing.T) {
+	client := &GotifyClient{failures: &callFailureStats{}}
+
+	var diags diag.Diagnostics
+	for i := 0; i < failureStormThreshold+2; i++ {
+		client.addFailure(&diags, "Timeout contacting Gotify", fmt.Sprintf("call %d timed out", i), "Raise the timeouts.")
+	}
+	client.addFailure(&diags, "Not Allowed", "GET /application returned 401 Unauthorized", "")
+
+	errs := diags.Errors()
+	if len(errs) != failureStormThreshold+3 {
+		t.Fatalf("expected an error per failure, got %v", diags)
+	}
+
+	if errs[0].Summary() != "Timeout contacting Gotify" || !strings.Contains(errs[0].Detail(), "Raise the timeouts.") {
+		t.Fatalf("expected the first failures to be described in full, got %v", errs[0])
+	}
+
+	last := errs[len(errs)-1]
+	if last.Summary() != "Not Allowed" || last.Detail() != "GET /application returned 401 Unauthorized" {
+		t.Fatalf("expected the following failures to keep their summary, got %v", last)
+	}
+	if strings.Contains(errs[failureStormThreshold].Detail(), "Raise the timeouts.") {
+		t.Fatalf("expected the following failures to drop their hint, got %v", errs[failureStormThreshold])
+	}
+
+	warnings := diags.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("expected a single aggregated warning, got %v", warnings)
+	}
+	expected := `6 calls to Gotify failed so far: 6 "Timeout contacting Gotify"`
+	if warnings[0].Summary() != "Repeated failures contacting Gotify" || !strings.Contains(warnings[0].Detail(), expected) {
+		t.Fatalf("expected the failures to be counted per category, got %v", warnings[0])
+	}
+}
+
+func TestAddFailureClientScoped(t *testing.T) {
+	first := &GotifyClient{failures: &callFailureStats{}}
+	second := &GotifyClient{failures: &callFailureStats{}}
+
+	var diags diag.Diagnostics
+	for i := 0; i < failureStormThreshold; i++ {
+		first.addFailure(&diags, "Timeout contacting Gotify", "timed out", "Raise the timeouts.")
+	}
+	second.addFailure(&diags, "Timeout contacting Gotify", "timed out", "Raise the timeouts.")
+
+	if len(diags.Warnings()) != 0 {
+		t.Fatalf("expected the failures of each client to be counted apart, got %v", diags.Warnings())
+	}
+	for _, err := range diags.Errors() {
+		if !strings.Contains(err.Detail(), "Raise the timeouts.") {
+			t.Fatalf("expected every failure to be described in full, got %v", err)
+		}
+	}
+}
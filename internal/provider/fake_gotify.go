@@ -0,0 +1,522 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// fakeApplication is an application stored by fakeGotify.
+type fakeApplication struct {
+	ID              int64  `json:"id"`
+	Token           string `json:"token"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	Internal        bool   `json:"internal"`
+	Image           string `json:"image"`
+	DefaultPriority int64  `json:"defaultPriority"`
+}
+
+// fakeClient is a client stored by fakeGotify.
+type fakeClient struct {
+	ID    int64  `json:"id"`
+	Token string `json:"token"`
+	Name  string `json:"name"`
+}
+
+// fakeMessage is a message stored by fakeGotify.
+type fakeMessage struct {
+	ID       int64  `json:"id"`
+	AppID    int64  `json:"appid"`
+	Message  string `json:"message"`
+	Title    string `json:"title"`
+	Priority int64  `json:"priority"`
+	Date     string `json:"date"`
+}
+
+// fakePlugin is a plugin installed on fakeGotify. Plugins can't be
+// installed through the API, so tests add them directly.
+type fakePlugin struct {
+	ID           int64    `json:"id"`
+	Name         string   `json:"name"`
+	Token        string   `json:"token"`
+	ModulePath   string   `json:"modulePath"`
+	Enabled      bool     `json:"enabled"`
+	Capabilities []string `json:"capabilities"`
+	Config       string   `json:"-"`
+}
+
+// fakeVersion is the Gotify version reported by fakeGotify.
+const fakeVersion = "2.4.0"
+
+// fakeFaults are the failures fakeGotify injects in the requests it serves,
+// to test how the provider behaves against a misbehaving server.
+type fakeFaults struct {
+	// Latency delays every answer, or until the request is cancelled.
+	Latency time.Duration
+	// ServerErrors is the number of next requests answered with a 503.
+	ServerErrors int
+	// Resets is the number of next requests whose connection is reset
+	// without any answer.
+	Resets int
+	// MalformedJSON answers every request with a truncated JSON body.
+	MalformedJSON bool
+}
+
+// fakeGotify is an in-memory implementation of the parts of the Gotify API
+// used by the provider. It accepts any credentials on the management
+// endpoints and application tokens when creating messages.
+type fakeGotify struct {
+	mu           sync.Mutex
+	nextID       int64
+	applications map[int64]*fakeApplication
+	clients      map[int64]*fakeClient
+	messages     map[int64]*fakeMessage
+	plugins      map[int64]*fakePlugin
+	images       map[string][]byte
+	faults       fakeFaults
+
+	// statePath is the file the changes are persisted to, if any.
+	statePath string
+}
+
+func newFakeGotify() *fakeGotify {
+	return &fakeGotify{
+		nextID:       1,
+		applications: map[int64]*fakeApplication{},
+		clients:      map[int64]*fakeClient{},
+		messages:     map[int64]*fakeMessage{},
+		plugins:      map[int64]*fakePlugin{},
+		images:       map[string][]byte{},
+	}
+}
+
+// handlerTransport serves requests with an http.Handler instead of sending
+// them over the network.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t *handlerTransport) RoundTrip(req *http.Request) (res *http.Response, err error) {
+	// Handlers abort with http.ErrAbortHandler to reset the connection,
+	// which the client sees as a network error.
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if recovered != http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			res = nil
+			err = &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+		}
+	}()
+
+	recorder := httptest.NewRecorder()
+	t.handler.ServeHTTP(recorder, req)
+
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	res = recorder.Result()
+	res.Request = req
+
+	return res, nil
+}
+
+// injectFaults makes f fail the next requests as described by faults.
+func (f *fakeGotify) injectFaults(faults fakeFaults) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.faults = faults
+}
+
+// serveFault answers r with the fault injected in f, if any, and reports
+// whether it did.
+func (f *fakeGotify) serveFault(w http.ResponseWriter, r *http.Request) bool {
+	f.mu.Lock()
+	faults := f.faults
+	switch {
+	case f.faults.Resets > 0:
+		f.faults.Resets--
+	case f.faults.ServerErrors > 0:
+		f.faults.ServerErrors--
+	}
+	f.mu.Unlock()
+
+	// The lock isn't held while waiting so other requests aren't delayed.
+	if faults.Latency > 0 {
+		select {
+		case <-time.After(faults.Latency):
+		case <-r.Context().Done():
+			return true
+		}
+	}
+
+	switch {
+	case faults.Resets > 0:
+		panic(http.ErrAbortHandler)
+	case faults.ServerErrors > 0:
+		fakeError(w, http.StatusServiceUnavailable, "injected server error")
+	case faults.MalformedJSON:
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":1,"name":`))
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (f *fakeGotify) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.serveFault(w, r) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Method != http.MethodGet {
+		defer f.save()
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case parts[0] == "application" && len(parts) == 1 && r.Method == http.MethodGet:
+		f.listApplications(w)
+	case parts[0] == "application" && len(parts) == 1 && r.Method == http.MethodPost:
+		f.createApplication(w, r)
+	case parts[0] == "application" && len(parts) == 2 && r.Method == http.MethodPut:
+		f.updateApplication(w, r, parts[1])
+	case parts[0] == "application" && len(parts) == 2 && r.Method == http.MethodDelete:
+		f.deleteApplication(w, parts[1])
+	case parts[0] == "application" && len(parts) == 3 && parts[2] == "image" && r.Method == http.MethodPost:
+		f.uploadApplicationImage(w, r, parts[1])
+	case parts[0] == "application" && len(parts) == 3 && parts[2] == "message" && r.Method == http.MethodGet:
+		if application, ok := f.application(w, parts[1]); ok {
+			f.listMessages(w, r, application.ID)
+		}
+	case parts[0] == "image" && len(parts) == 2 && r.Method == http.MethodGet:
+		f.serveImage(w, strings.Join(parts, "/"))
+	case parts[0] == "client" && len(parts) == 1 && r.Method == http.MethodGet:
+		f.listClients(w)
+	case parts[0] == "plugin" && len(parts) == 1 && r.Method == http.MethodGet:
+		f.listPlugins(w)
+	case parts[0] == "plugin" && len(parts) == 3 && (parts[2] == "enable" || parts[2] == "disable") && r.Method == http.MethodPost:
+		if plugin, ok := f.plugin(w, parts[1]); ok {
+			plugin.Enabled = parts[2] == "enable"
+		}
+	case parts[0] == "plugin" && len(parts) == 3 && parts[2] == "config" && r.Method == http.MethodGet:
+		if plugin, ok := f.plugin(w, parts[1]); ok {
+			w.Header().Set("Content-Type", "application/x-yaml")
+			_, _ = w.Write([]byte(plugin.Config))
+		}
+	case parts[0] == "plugin" && len(parts) == 3 && parts[2] == "config" && r.Method == http.MethodPost:
+		if plugin, ok := f.plugin(w, parts[1]); ok {
+			f.updatePluginConfig(w, r, plugin)
+		}
+	case parts[0] == "message" && len(parts) == 1 && r.Method == http.MethodGet:
+		f.listMessages(w, r, 0)
+	case parts[0] == "message" && len(parts) == 1 && r.Method == http.MethodPost:
+		f.createMessage(w, r)
+	case parts[0] == "message" && len(parts) == 2 && r.Method == http.MethodDelete:
+		f.deleteMessage(w, parts[1])
+	case parts[0] == "health" && len(parts) == 1 && r.Method == http.MethodGet:
+		fakeJSON(w, map[string]string{"health": "green", "database": "green"})
+	case parts[0] == "version" && len(parts) == 1 && r.Method == http.MethodGet:
+		fakeJSON(w, map[string]string{"version": fakeVersion, "commit": "fake", "buildDate": "2024-01-01T00:00:00Z"})
+	default:
+		fakeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+	}
+}
+
+func (f *fakeGotify) listApplications(w http.ResponseWriter) {
+	applications := []*fakeApplication{}
+	for _, application := range f.applications {
+		applications = append(applications, application)
+	}
+	sort.Slice(applications, func(i, j int) bool { return applications[i].ID < applications[j].ID })
+
+	fakeJSON(w, applications)
+}
+
+func (f *fakeGotify) createApplication(w http.ResponseWriter, r *http.Request) {
+	var application fakeApplication
+	if err := json.NewDecoder(r.Body).Decode(&application); err != nil || application.Name == "" {
+		fakeError(w, http.StatusBadRequest, "invalid application")
+		return
+	}
+
+	application.ID = f.newID()
+	application.Token = fakeToken("A")
+	application.Image = defaultImage
+	f.applications[application.ID] = &application
+
+	fakeJSON(w, application)
+}
+
+func (f *fakeGotify) updateApplication(w http.ResponseWriter, r *http.Request, id string) {
+	application, ok := f.application(w, id)
+	if !ok {
+		return
+	}
+
+	var update fakeApplication
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil || update.Name == "" {
+		fakeError(w, http.StatusBadRequest, "invalid application")
+		return
+	}
+
+	application.Name = update.Name
+	application.Description = update.Description
+	application.DefaultPriority = update.DefaultPriority
+
+	fakeJSON(w, application)
+}
+
+func (f *fakeGotify) deleteApplication(w http.ResponseWriter, id string) {
+	application, ok := f.application(w, id)
+	if !ok {
+		return
+	}
+
+	delete(f.applications, application.ID)
+	for messageID, message := range f.messages {
+		if message.AppID == application.ID {
+			delete(f.messages, messageID)
+		}
+	}
+}
+
+func (f *fakeGotify) uploadApplicationImage(w http.ResponseWriter, r *http.Request, id string) {
+	application, ok := f.application(w, id)
+	if !ok {
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		fakeError(w, http.StatusBadRequest, "missing file")
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		fakeError(w, http.StatusBadRequest, "unreadable file")
+		return
+	}
+
+	application.Image = fmt.Sprintf("image/%d.png", application.ID)
+	f.images[application.Image] = content
+
+	fakeJSON(w, application)
+}
+
+func (f *fakeGotify) serveImage(w http.ResponseWriter, image string) {
+	content, ok := f.images[image]
+	if !ok {
+		fakeError(w, http.StatusNotFound, "image not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	_, _ = w.Write(content)
+}
+
+func (f *fakeGotify) listClients(w http.ResponseWriter) {
+	clients := []*fakeClient{}
+	for _, client := range f.clients {
+		clients = append(clients, client)
+	}
+	sort.Slice(clients, func(i, j int) bool { return clients[i].ID < clients[j].ID })
+
+	fakeJSON(w, clients)
+}
+
+// listMessages lists the messages of the application appID, of every
+// application when 0.
+func (f *fakeGotify) listMessages(w http.ResponseWriter, r *http.Request, appID int64) {
+	limit := int64(100)
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, _ = strconv.ParseInt(value, 10, 64)
+	}
+
+	since, _ := strconv.ParseInt(r.URL.Query().Get("since"), 10, 64)
+
+	// Messages are listed newest first, starting below since when set.
+	messages := []*fakeMessage{}
+	for _, message := range f.messages {
+		if (since == 0 || message.ID < since) && (appID == 0 || message.AppID == appID) {
+			messages = append(messages, message)
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID > messages[j].ID })
+
+	paging := map[string]interface{}{
+		"limit": limit,
+		"size":  len(messages),
+		"since": 0,
+	}
+
+	if int64(len(messages)) > limit {
+		messages = messages[:limit]
+		last := messages[len(messages)-1].ID
+		paging["since"] = last
+		paging["next"] = fmt.Sprintf("%s?limit=%d&since=%d", r.URL.Path, limit, last)
+	}
+	paging["size"] = len(messages)
+
+	fakeJSON(w, map[string]interface{}{
+		"messages": messages,
+		"paging":   paging,
+	})
+}
+
+func (f *fakeGotify) createMessage(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("X-Gotify-Key")
+	if token == "" {
+		token = r.URL.Query().Get("token")
+	}
+
+	var application *fakeApplication
+	for _, candidate := range f.applications {
+		if candidate.Token == token {
+			application = candidate
+		}
+	}
+
+	if application == nil {
+		fakeError(w, http.StatusUnauthorized, "you need to provide a valid access token or user credentials to access this api")
+		return
+	}
+
+	var message fakeMessage
+	if err := json.NewDecoder(r.Body).Decode(&message); err != nil || message.Message == "" {
+		fakeError(w, http.StatusBadRequest, "invalid message")
+		return
+	}
+
+	message.ID = f.newID()
+	message.AppID = application.ID
+	message.Date = time.Now().UTC().Format(time.RFC3339)
+	if message.Title == "" {
+		message.Title = application.Name
+	}
+	f.messages[message.ID] = &message
+
+	fakeJSON(w, message)
+}
+
+func (f *fakeGotify) deleteMessage(w http.ResponseWriter, id string) {
+	messageID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || f.messages[messageID] == nil {
+		fakeError(w, http.StatusNotFound, fmt.Sprintf("message with id %s doesn't exists", id))
+		return
+	}
+
+	delete(f.messages, messageID)
+}
+
+// application returns the application identified by id, answering a 404
+// when it doesn't exist.
+func (f *fakeGotify) application(w http.ResponseWriter, id string) (*fakeApplication, bool) {
+	applicationID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || f.applications[applicationID] == nil {
+		fakeError(w, http.StatusNotFound, fmt.Sprintf("app with id %s doesn't exists", id))
+		return nil, false
+	}
+
+	return f.applications[applicationID], true
+}
+
+func (f *fakeGotify) listPlugins(w http.ResponseWriter) {
+	plugins := []*fakePlugin{}
+	for _, plugin := range f.plugins {
+		plugins = append(plugins, plugin)
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].ID < plugins[j].ID })
+
+	fakeJSON(w, plugins)
+}
+
+// updatePluginConfig stores the YAML configuration of plugin. Gotify decodes
+// and encodes it again, so it is answered back in its own formatting, which
+// is mimicked by trimming it.
+func (f *fakeGotify) updatePluginConfig(w http.ResponseWriter, r *http.Request, plugin *fakePlugin) {
+	// Like Gotify, plugins are configured while disabled.
+	if plugin.Enabled {
+		fakeError(w, http.StatusBadRequest, "plugin must be disabled to be configured")
+		return
+	}
+
+	config, err := io.ReadAll(r.Body)
+	if err != nil || strings.TrimSpace(string(config)) == "" {
+		fakeError(w, http.StatusBadRequest, "invalid plugin configuration")
+		return
+	}
+
+	plugin.Config = strings.TrimSpace(string(config)) + "\n"
+}
+
+func (f *fakeGotify) plugin(w http.ResponseWriter, id string) (*fakePlugin, bool) {
+	pluginID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || f.plugins[pluginID] == nil {
+		fakeError(w, http.StatusNotFound, fmt.Sprintf("plugin with id %s doesn't exists", id))
+		return nil, false
+	}
+
+	return f.plugins[pluginID], true
+}
+
+func (f *fakeGotify) newID() int64 {
+	id := f.nextID
+	f.nextID++
+
+	return id
+}
+
+// fakeToken returns a random token of the same shape as Gotify's.
+func fakeToken(prefix string) string {
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789.-_"
+
+	token := prefix
+	for len(token) < 15 {
+		n, _ := rand.Int(rand.Reader, big.NewInt(int64(len(letters))))
+		token += string(letters[n.Int64()])
+	}
+
+	return token
+}
+
+func fakeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func fakeError(w http.ResponseWriter, status int, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(gotifyError{
+		Error:            http.StatusText(status),
+		ErrorCode:        status,
+		ErrorDescription: description,
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// fakeGotifyState is what fakeGotify persists between the runs of the
+// provider.
+type fakeGotifyState struct {
+	NextID        int64                      `json:"next_id"`
+	Applications  map[int64]*fakeApplication `json:"applications"`
+	Clients       map[int64]*fakeClient      `json:"clients"`
+	Messages      map[int64]*fakeMessage     `json:"messages"`
+	Plugins       map[int64]*fakePlugin      `json:"plugins"`
+	PluginConfigs map[int64]string           `json:"plugin_configs"`
+	Images        map[string][]byte          `json:"images"`
+}
+
+// mockFakes are the fakes of mock mode by the file they are persisted to,
+// so the provider configurations of a run share one.
+var mockFakes = struct {
+	sync.Mutex
+	fakes map[string]*fakeGotify
+}{fakes: map[string]*fakeGotify{}}
+
+// mockStatePath returns the file the fake of mock mode is persisted to when
+// Terraform runs from dir. Terraform starts the provider again for every
+// command, e.g. plan then apply, which would otherwise each see an empty
+// fake.
+func mockStatePath(dir string) string {
+	sum := sha256.Sum256([]byte(dir))
+	return filepath.Join(os.TempDir(), fmt.Sprintf("terraform-provider-gotify-mock-%x.json", sum[:8]))
+}
+
+// mockGotify returns the fake of mock mode persisted to path, loaded from
+// it the first time. A file which can't be read starts an empty fake.
+func mockGotify(path string) *fakeGotify {
+	mockFakes.Lock()
+	defer mockFakes.Unlock()
+
+	if fake, ok := mockFakes.fakes[path]; ok {
+		return fake
+	}
+
+	fake := newFakeGotify()
+	if err := fake.load(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("[WARN] Can't load the state of the mock Gotify from %s, starting empty: %s", path, err)
+	}
+	fake.statePath = path
+
+	mockFakes.fakes[path] = fake
+
+	return fake
+}
+
+// load replaces the content of f with the state persisted to path.
+func (f *fakeGotify) load(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var state fakeGotifyState
+	if err := json.Unmarshal(content, &state); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextID = state.NextID
+	for id, application := range state.Applications {
+		f.applications[id] = application
+	}
+	for id, client := range state.Clients {
+		f.clients[id] = client
+	}
+	for id, message := range state.Messages {
+		f.messages[id] = message
+	}
+	for id, plugin := range state.Plugins {
+		plugin.Config = state.PluginConfigs[id]
+		f.plugins[id] = plugin
+	}
+	for image, content := range state.Images {
+		f.images[image] = content
+	}
+
+	return nil
+}
+
+// save persists the content of f to its state file, if any. f.mu must be
+// held.
+func (f *fakeGotify) save() {
+	if f.statePath == "" {
+		return
+	}
+
+	state := fakeGotifyState{
+		NextID:        f.nextID,
+		Applications:  f.applications,
+		Clients:       f.clients,
+		Messages:      f.messages,
+		Plugins:       f.plugins,
+		PluginConfigs: map[int64]string{},
+		Images:        f.images,
+	}
+	for id, plugin := range f.plugins {
+		state.PluginConfigs[id] = plugin.Config
+	}
+
+	content, err := json.Marshal(state)
+	if err == nil {
+		// The state is replaced at once, so an interrupted run doesn't
+		// leave a truncated file.
+		tmp := f.statePath + ".tmp"
+		if err = os.WriteFile(tmp, content, 0o600); err == nil {
+			err = os.Rename(tmp, f.statePath)
+		}
+	}
+
+	if err != nil {
+		log.Printf("[WARN] Can't persist the state of the mock Gotify to %s: %s", f.statePath, err)
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFakeGotifyApplications(t *testing.T) {
+	client := &http.Client{
+		Transport: newTransport(GotifyProviderModel{Mock: types.BoolValue(true)}, nil, ""),
+	}
+
+	res, err := client.Post(mockUrl+"/application", "application/json", strings.NewReader(`{"name":"app","defaultPriority":5}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var created fakeApplication
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if created.ID == 0 || len(created.Token) != 15 || !strings.HasPrefix(created.Token, "A") {
+		t.Fatalf("unexpected application %+v", created)
+	}
+
+	// Messages sent with the application token are listed.
+	req, _ := http.NewRequest(http.MethodPost, mockUrl+"/message", strings.NewReader(`{"message":"hello"}`))
+	req.Header.Set("X-Gotify-Key", created.Token)
+	res, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected message to be created, got %s", res.Status)
+	}
+
+	req, _ = http.NewRequest(http.MethodDelete, mockUrl+"/application/42", nil)
+	res, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 deleting a missing application, got %s", res.Status)
+	}
+
+	res, err = client.Get(mockUrl + "/message?limit=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var messages struct {
+		Messages []fakeMessage `json:"messages"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&messages); err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if len(messages.Messages) != 1 || messages.Messages[0].AppID != created.ID || messages.Messages[0].Title != "app" {
+		t.Fatalf("unexpected messages %+v", messages.Messages)
+	}
+}
+
+func TestFakeGotifyFaults(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		faults      fakeFaults
+		endpoints   []string
+		wantSummary string
+		wantDetail  string
+	}{
+		"latency under the read timeout": {
+			faults: fakeFaults{Latency: 10 * time.Millisecond},
+		},
+		"latency over the read timeout": {
+			faults:      fakeFaults{Latency: time.Minute},
+			wantSummary: "Timeout contacting Gotify",
+		},
+		"server errors": {
+			faults:      fakeFaults{ServerErrors: 3},
+			wantSummary: "API Error when contacting Gotify instance",
+			wantDetail:  "503 Service Unavailable",
+		},
+		"malformed json": {
+			faults:      fakeFaults{MalformedJSON: true},
+			wantSummary: "API Error when contacting Gotify instance",
+			wantDetail:  "unexpected EOF",
+		},
+		"connection reset": {
+			faults:      fakeFaults{Resets: 1},
+			wantSummary: "Can't contact Gotify Instance",
+			wantDetail:  "connection reset by peer",
+		},
+		"connection reset with failover": {
+			faults:    fakeFaults{Resets: 1},
+			endpoints: []string{mockUrl, "http://gotify-b.mock"},
+		},
+		"connection resets on every endpoint": {
+			faults:      fakeFaults{Resets: 2},
+			endpoints:   []string{mockUrl, "http://gotify-b.mock"},
+			wantSummary: "Can't contact Gotify Instance",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake := newFakeGotify()
+			fake.injectFaults(test.faults)
+
+			config := GotifyProviderModel{
+				Url:             NewURLValue(mockUrl),
+				Mock:            types.BoolValue(true),
+				DefaultTimeouts: &TimeoutsModel{Read: types.StringValue("100ms")},
+			}
+
+			transport := newTransport(config, test.endpoints, "").(*gotifyTransport)
+			transport.base = &handlerTransport{handler: fake}
+
+			d := &HealthDataSource{
+				client: &GotifyClient{Client: &http.Client{Transport: transport}, Config: config},
+			}
+
+			schemaResp := &datasource.SchemaResponse{}
+			d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			if diags := state.Set(ctx, &HealthDataSourceModel{Id: types.StringNull(), Health: types.StringNull(), Database: types.StringNull(), Retries: types.Int64Null(), Interval: types.StringNull()}); diags.HasError() {
+				t.Fatalf("can't build config: %v", diags)
+			}
+
+			resp := &datasource.ReadResponse{State: state}
+			d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+
+			if test.wantSummary == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+				}
+
+				return
+			}
+
+			errs := resp.Diagnostics.Errors()
+			if len(errs) != 1 || errs[0].Summary() != test.wantSummary || !strings.Contains(errs[0].Detail(), test.wantDetail) {
+				t.Fatalf("expected a %q error containing %q, got %v", test.wantSummary, test.wantDetail, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestFakeGotifyFailover(t *testing.T) {
+	endpoints := []string{mockUrl, "http://gotify-b.mock", "http://gotify-c.mock"}
+
+	tests := map[string]struct {
+		resets    int
+		method    string
+		path      string
+		body      string
+		wantHosts []string
+		wantError bool
+		// nextHost is the endpoint the following request is sent to.
+		nextHost string
+	}{
+		"read after a reset": {
+			resets:    1,
+			method:    http.MethodGet,
+			path:      "/application",
+			wantHosts: []string{"gotify.mock", "gotify-b.mock"},
+			nextHost:  "gotify-b.mock",
+		},
+		"read after resets on two endpoints": {
+			resets:    2,
+			method:    http.MethodGet,
+			path:      "/application",
+			wantHosts: []string{"gotify.mock", "gotify-b.mock", "gotify-c.mock"},
+			nextHost:  "gotify-c.mock",
+		},
+		"resets on every endpoint": {
+			resets:    3,
+			method:    http.MethodGet,
+			path:      "/application",
+			wantHosts: []string{"gotify.mock", "gotify-b.mock", "gotify-c.mock"},
+			wantError: true,
+			nextHost:  "gotify.mock",
+		},
+		"update resent with its body": {
+			resets:    1,
+			method:    http.MethodPut,
+			path:      "/application/1",
+			body:      `{"name":"renamed","defaultPriority":5}`,
+			wantHosts: []string{"gotify.mock", "gotify-b.mock"},
+			nextHost:  "gotify-b.mock",
+		},
+		"creation not retried": {
+			resets:    1,
+			method:    http.MethodPost,
+			path:      "/application",
+			body:      `{"name":"created","defaultPriority":5}`,
+			wantHosts: []string{"gotify.mock"},
+			wantError: true,
+			nextHost:  "gotify-b.mock",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake := newFakeGotify()
+			fake.applications[1] = &fakeApplication{ID: 1, Token: "AExisting", Name: "existing", DefaultPriority: 5}
+			fake.nextID = 2
+			fake.injectFaults(fakeFaults{Resets: test.resets})
+
+			// The endpoint each attempt is sent to is recorded.
+			var hosts []string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hosts = append(hosts, r.URL.Host)
+				fake.ServeHTTP(w, r)
+			})
+
+			transport := newTransport(GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)}, endpoints, "").(*gotifyTransport)
+			transport.base = &handlerTransport{handler: handler}
+			client := &http.Client{Transport: transport}
+
+			req, err := http.NewRequest(test.method, mockUrl+test.path, strings.NewReader(test.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := client.Do(req)
+			if err == nil {
+				res.Body.Close()
+			}
+
+			if (err != nil) != test.wantError {
+				t.Fatalf("expected error %t, got %v", test.wantError, err)
+			}
+			if strings.Join(hosts, ",") != strings.Join(test.wantHosts, ",") {
+				t.Fatalf("expected attempts on %v, got %v", test.wantHosts, hosts)
+			}
+
+			switch {
+			case test.method == http.MethodPut && fake.applications[1].Name != "renamed":
+				t.Fatalf("expected the update to be applied once resent, got %+v", fake.applications[1])
+			case test.method == http.MethodPost && len(fake.applications) != 1:
+				t.Fatalf("expected the creation not to be resent, got %d applications", len(fake.applications))
+			}
+
+			// The endpoint failed over to is kept for the following requests.
+			hosts = nil
+			res, err = client.Get(mockUrl + "/application")
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+
+			if len(hosts) != 1 || hosts[0] != test.nextHost {
+				t.Fatalf("expected the next request to be sent to %s, got %v", test.nextHost, hosts)
+			}
+		})
+	}
+}
+
+// TestMockGotifyPersisted runs the commands of a Terraform run against mock
+// mode, each starting the provider again, and checks the application
+// created by the first one is still read by the next ones.
+func TestMockGotifyPersisted(t *testing.T) {
+	ctx := context.Background()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every command starts a new provider process, without the fakes of the
+	// previous one.
+	command := func() *ApplicationResource {
+		mockFakes.Lock()
+		mockFakes.fakes = map[string]*fakeGotify{}
+		mockFakes.Unlock()
+
+		return &ApplicationResource{
+			client: &GotifyClient{
+				Client: &http.Client{Transport: newTransport(GotifyProviderModel{Mock: types.BoolValue(true)}, nil, "")},
+				Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+			},
+		}
+	}
+
+	r := command()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := plan.Set(ctx, &ApplicationResourceModel{
+		Name:                 types.StringValue("alerts"),
+		Description:          NewDescriptionValue("Alerts"),
+		Priority:             NewPriorityValue("5"),
+		Id:                   types.StringUnknown(),
+		Token:                types.StringUnknown(),
+		Image:                types.StringNull(),
+		TokenRotationTrigger: types.MapNull(types.StringType),
+	})
+	if diags.HasError() {
+		t.Fatalf("can't build plan: %v", diags)
+	}
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", createResp.Diagnostics)
+	}
+
+	var created ApplicationResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(ctx, &created)...)
+
+	for _, step := range []string{"plan", "apply"} {
+		readResp := &fwresource.ReadResponse{State: createResp.State}
+		command().Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+		if readResp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected errors: %v", step, readResp.Diagnostics)
+		}
+
+		var data ApplicationResourceModel
+		readResp.Diagnostics.Append(readResp.State.Get(ctx, &data)...)
+		if readResp.State.Raw.IsNull() || data.Id != created.Id || data.Token != created.Token {
+			t.Fatalf("%s: expected the application %s to be read again, got %+v", step, created.Id, data)
+		}
+	}
+
+	// Ids keep increasing across the commands.
+	if fake := mockGotify(mockStatePath(dir)); fake.nextID != created.ApplicationId.ValueInt64()+1 {
+		t.Fatalf("expected the next id to follow %s, got %d", created.Id, fake.nextID)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// featureVersion is the oldest version of Gotify a feature of the provider
+// works with.
+type featureVersion struct {
+	feature string
+	minimum [3]int
+}
+
+// featureVersions are the features of the provider which misbehave with old
+// Gotify servers.
+var featureVersions = []featureVersion{
+	// Plugins were introduced with Gotify 2.
+	{feature: "the gotify_plugins and gotify_plugin_config data sources", minimum: [3]int{2, 0, 0}},
+	// Older servers ignore the default priority of applications.
+	{feature: "the priority of gotify_application", minimum: [3]int{2, 2, 0}},
+}
+
+// parseVersion parses a Gotify version such as 2.4.0 or v2.0.1.
+func parseVersion(version string) ([3]int, error) {
+	var parsed [3]int
+
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) != 3 {
+		return parsed, fmt.Errorf("version %q isn't made of a major, minor and patch number", version)
+	}
+
+	for i, part := range parts {
+		// Pre-releases, such as 2.5.0-beta, are compared as their release.
+		part, _, _ = strings.Cut(part, "-")
+
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return parsed, fmt.Errorf("version %q isn't made of a major, minor and patch number", version)
+		}
+		parsed[i] = number
+	}
+
+	return parsed, nil
+}
+
+// olderVersion reports whether version a precedes version b.
+func olderVersion(a, b [3]int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+
+	return false
+}
+
+// checkFeatureVersions returns a warning for each feature of the provider
+// which may misbehave with the Gotify server running version. Versions
+// which can't be parsed, e.g. of development builds, aren't checked.
+func checkFeatureVersions(version string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	parsed, err := parseVersion(version)
+	if err != nil {
+		return diags
+	}
+
+	for _, feature := range featureVersions {
+		if olderVersion(parsed, feature.minimum) {
+			minimum := fmt.Sprintf("%d.%d.%d", feature.minimum[0], feature.minimum[1], feature.minimum[2])
+			diags.AddWarning(
+				"Outdated Gotify server",
+				fmt.Sprintf("The Gotify server runs version %s, %s need Gotify %s or later and may misbehave. Upgrade Gotify to use them.", version, feature.feature, minimum),
+			)
+		}
+	}
+
+	return diags
+}
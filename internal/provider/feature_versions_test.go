@@ -0,0 +1,26 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestCheckFeatureVersions(t *testing.T) {
+	tests := map[string]int{
+		"2.4.0":      0,
+		"v2.2.0":     0,
+		"2.1.7":      1,
+		"2.2.0-rc.1": 0,
+		"1.2.1":      2,
+		"unknown":    0,
+		"":           0,
+	}
+
+	for version, warnings := range tests {
+		if diags := checkFeatureVersions(version); diags.HasError() || diags.WarningsCount() != warnings {
+			t.Fatalf("expected %d warnings for version %q, got %v", warnings, version, diags)
+		}
+	}
+}
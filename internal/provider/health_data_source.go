@@ -0,0 +1,212 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HealthDataSource{}
+
+func NewHealthDataSource() datasource.DataSource {
+	return &HealthDataSource{}
+}
+
+// HealthDataSource defines the data source implementation.
+type HealthDataSource struct {
+	client *GotifyClient
+}
+
+// HealthDataSourceModel describes the data source data model.
+type HealthDataSourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	Health   types.String `tfsdk:"health"`
+	Database types.String `tfsdk:"database"`
+	Retries  types.Int64  `tfsdk:"retries"`
+	Interval types.String `tfsdk:"interval"`
+}
+
+// defaultHealthInterval is the time waited between two health checks when
+// interval isn't set.
+const defaultHealthInterval = 5 * time.Second
+
+// healthResponse is the health of Gotify, as answered to /health.
+type healthResponse struct {
+	Health   string `json:"health"`
+	Database string `json:"database"`
+}
+
+// healthy reports whether both Gotify and its database are healthy.
+func (h healthResponse) healthy() bool {
+	return h.Health == "green" && h.Database == "green"
+}
+
+func (d *HealthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_health"
+}
+
+func (d *HealthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Health of the Gotify instance. Doesn't require credentials",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Placeholder identifier",
+			},
+			"health": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Health of the Gotify server, `green` when healthy",
+			},
+			"database": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Health of the database of the Gotify server, `green` when healthy",
+			},
+			"retries": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of times the health is checked again while Gotify is unreachable or unhealthy, e.g. to wait for an instance created in the same configuration. The read timeout of the provider bounds the wait. Defaults to `0`",
+			},
+			"interval": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Time waited between two checks when `retries` is set, as a duration such as `5s`. Defaults to `5s`",
+			},
+		},
+	}
+}
+
+func (d *HealthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*GotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *GotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *HealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HealthDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := d.client.operationContext(ctx, "read", nil)
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	if !data.Retries.IsNull() && data.Retries.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("retries"), "Invalid retries", "retries can't be negative")
+		return
+	}
+
+	interval := defaultHealthInterval
+	if !data.Interval.IsNull() {
+		var err error
+		if interval, err = time.ParseDuration(data.Interval.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("interval"), "Invalid interval", err.Error())
+			return
+		} else if interval < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("interval"), "Invalid interval", "interval can't be negative")
+			return
+		}
+	}
+
+	var respData healthResponse
+	for attempt := int64(0); ; attempt++ {
+		var diags diag.Diagnostics
+		respData, diags = d.health(ctx)
+
+		if (!diags.HasError() && respData.healthy()) || attempt >= data.Retries.ValueInt64() {
+			resp.Diagnostics.Append(diags...)
+			break
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Gotify isn't healthy yet, checking again in %s (%d/%d)", interval, attempt+1, data.Retries.ValueInt64()))
+
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics.AddError("Timeout waiting for Gotify to be healthy", fmt.Sprintf("Gotify wasn't healthy before the read timeout elapsed: %s", ctx.Err()))
+			return
+		case <-time.After(interval):
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue("health")
+	data.Health = types.StringValue(respData.Health)
+	data.Database = types.StringValue(respData.Database)
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// health reads the health of Gotify.
+func (d *HealthDataSource) health(ctx context.Context) (healthResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var respData healthResponse
+
+	url := strings.Trim(d.client.Config.Url.String(), "\"")
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url+"/health", nil)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		diags.AddError("Can't send request to Gotify", err.Error())
+		return respData, diags
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpRes, err := d.client.Do(httpReq)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		d.client.addRequestError(&diags, err)
+		return respData, diags
+	}
+
+	defer httpRes.Body.Close()
+
+	// Gotify answers 500 with the same body when its database is down.
+	if err := checkResponse(httpRes); err != nil && httpRes.StatusCode != 500 {
+		d.client.addResponseError(&diags, err)
+		return respData, diags
+	}
+
+	err = d.client.decode(httpRes.Body, &respData)
+	if err != nil {
+		diags.AddError("API Error when contacting Gotify instance", err.Error())
+	}
+
+	return respData, diags
+}
@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHealthDataSource(t *testing.T) {
+	testAccVCR(t, "health_data_source")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing, without any credentials
+			{
+				Config: testAccHealthDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gotify_health.test", "health", "green"),
+					resource.TestCheckResourceAttr("data.gotify_health.test", "database", "green"),
+				),
+			},
+		},
+	})
+}
+
+func testAccHealthDataSourceConfig() string {
+	return fmt.Sprintf(`
+provider "gotify" {
+  url = %q
+}
+
+data "gotify_health" "test" {}
+`, os.Getenv("GOTIFY_URL"))
+}
+
+func TestHealthDataSourceRetries(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		retries   int64
+		wantError bool
+	}{
+		"healthy in time": {retries: 3},
+		"too few retries": {retries: 1, wantError: true},
+		"no retries":      {retries: 0, wantError: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+
+			// Gotify is still starting for the first two checks.
+			var checks int
+			fake := newFakeGotify()
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				checks++
+				if checks <= 2 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				fake.ServeHTTP(w, r)
+			})
+
+			config := GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)}
+			transport := newTransport(config, nil, "").(*gotifyTransport)
+			transport.base = &handlerTransport{handler: handler}
+
+			d := &HealthDataSource{
+				client: &GotifyClient{Client: &http.Client{Transport: transport}, Config: config},
+			}
+
+			schemaResp := &datasource.SchemaResponse{}
+			d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			diags := state.Set(ctx, &HealthDataSourceModel{
+				Id:       types.StringNull(),
+				Health:   types.StringNull(),
+				Database: types.StringNull(),
+				Retries:  types.Int64Value(test.retries),
+				Interval: types.StringValue("1ms"),
+			})
+			if diags.HasError() {
+				t.Fatalf("can't build config: %v", diags)
+			}
+
+			resp := &datasource.ReadResponse{State: state}
+			d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+
+			if test.wantError {
+				if !resp.Diagnostics.HasError() || checks != int(test.retries)+1 {
+					t.Fatalf("expected an error after %d checks, got %d checks and %v", test.retries+1, checks, resp.Diagnostics)
+				}
+				return
+			}
+
+			var data HealthDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if resp.Diagnostics.HasError() || data.Health.ValueString() != "green" || checks != 3 {
+				t.Fatalf("expected Gotify to be healthy after 3 checks, got %d checks, %+v and %v", checks, data, resp.Diagnostics)
+			}
+		})
+	}
+}
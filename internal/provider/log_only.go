@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logOnlyFirstID is the first synthetic id of the objects created in
+// log_only mode, far above the ids of real objects so they aren't mistaken
+// for each other.
+const logOnlyFirstID = 1_000_000_000
+
+// logOnlyIDs allocates the synthetic ids of the objects created in log_only
+// mode.
+var logOnlyIDs atomic.Int64
+
+// logOnlyRoundTrip logs req, a call modifying Gotify, instead of sending it,
+// and answers it as Gotify would: the JSON payload is echoed with the id of
+// the object, a synthetic one when it is created, and a synthetic token.
+func logOnlyRoundTrip(req *http.Request) (*http.Response, error) {
+	var payload []byte
+	if req.Body != nil {
+		var err error
+		payload, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tflog.Info(req.Context(), fmt.Sprintf("log_only: not sending %s %s %s", req.Method, req.URL.Path, describePayload(req, payload)))
+
+	body := map[string]interface{}{}
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		// Payloads which aren't JSON objects are only logged.
+		_ = json.Unmarshal(payload, &body)
+	}
+
+	id, ok := pathID(req.URL.Path)
+	if !ok {
+		id = logOnlyFirstID + logOnlyIDs.Add(1) - 1
+	}
+	body["id"] = id
+	if _, ok := body["token"]; !ok {
+		body["token"] = fmt.Sprintf("Alogonly%07d", id%10_000_000)
+	}
+
+	answer, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(answer)),
+		Request:    req,
+	}, nil
+}
+
+// describePayload describes payload, the body of req, for the logs: JSON
+// payloads are logged with their tokens redacted, others by their size.
+func describePayload(req *http.Request, payload []byte) string {
+	if len(payload) == 0 {
+		return "without payload"
+	}
+
+	if !strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return fmt.Sprintf("with a %s payload of %d bytes", req.Header.Get("Content-Type"), len(payload))
+	}
+
+	return "with payload " + redactTokens(string(payload))
+}
+
+// pathID returns the id of the object path refers to, e.g. 42 for
+// /application/42 or /application/42/image, and false for collections.
+func pathID(path string) (int64, bool) {
+	for _, part := range strings.Split(path, "/") {
+		if id, err := strconv.ParseInt(part, 10, 64); err == nil {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MessageDataSource{}
+
+func NewMessageDataSource() datasource.DataSource {
+	return &MessageDataSource{}
+}
+
+// MessageDataSource defines the data source implementation.
+type MessageDataSource struct {
+	client *GotifyClient
+}
+
+// MessageDataSourceModel describes the data source data model.
+type MessageDataSourceModel struct {
+	Id            types.String  `tfsdk:"id"`
+	ApplicationId types.String  `tfsdk:"application_id"`
+	Title         types.String  `tfsdk:"title"`
+	Message       types.String  `tfsdk:"message"`
+	Priority      PriorityValue `tfsdk:"priority"`
+	Date          types.String  `tfsdk:"date"`
+	FailIfMissing types.Bool    `tfsdk:"fail_if_missing"`
+	Found         types.Bool    `tfsdk:"found"`
+}
+
+func (d *MessageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_message"
+}
+
+func (d *MessageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Message data source",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Message identifier",
+			},
+			"application_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the application which sent the message",
+			},
+			"title": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Title of the message",
+			},
+			"message": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Content of the message",
+			},
+			"priority": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Priority of the message",
+				CustomType:          PriorityType{},
+			},
+			"date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Date the message was sent at",
+			},
+			"fail_if_missing": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Fail when the message doesn't exist. When `false`, `found` is set to `false` and the message attributes are left null instead. Defaults to `true`",
+			},
+			"found": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the message exists",
+			},
+		},
+	}
+}
+
+func (d *MessageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*GotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *GotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *MessageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MessageDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := d.client.operationContext(ctx, "read", nil)
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	url := strings.Trim(d.client.Config.Url.String(), "\"")
+
+	id, err := strconv.ParseInt(data.Id.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Message id cannot be parsed as Int", err.Error())
+		return
+	}
+
+	// Gotify has no endpoint returning a single message, but the list endpoint
+	// returns messages with an id lower than since, newest first.
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/message?limit=1&since=%d", url, id+1), nil)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		resp.Diagnostics.AddError("Can't send request to Gotify", err.Error())
+		return
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpRes, err := d.client.Do(httpReq)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		d.client.addRequestError(&resp.Diagnostics, err)
+		return
+	}
+	defer httpRes.Body.Close()
+
+	if err := checkResponse(httpRes); err != nil {
+		d.client.addResponseError(&resp.Diagnostics, err)
+		return
+	}
+
+	type JsonReponse struct {
+		Messages []struct {
+			ID       int64                  `json:"id"`
+			AppID    int64                  `json:"appid"`
+			Message  string                 `json:"message"`
+			Title    string                 `json:"title"`
+			Priority int64                  `json:"priority"`
+			Extras   map[string]interface{} `json:"extras"`
+			Date     string                 `json:"date"`
+		} `json:"messages"`
+		Paging struct {
+			Limit int64  `json:"limit"`
+			Next  string `json:"next"`
+			Since int64  `json:"since"`
+			Size  int64  `json:"size"`
+		} `json:"paging"`
+	}
+
+	var respData JsonReponse
+
+	err = d.client.decode(httpRes.Body, &respData)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error when contacting Gotify instance", err.Error())
+		return
+	}
+
+	if len(respData.Messages) == 0 || respData.Messages[0].ID != id {
+		if !data.FailIfMissing.IsNull() && !data.FailIfMissing.ValueBool() {
+			data.Found = types.BoolValue(false)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+
+		resp.Diagnostics.AddError("API Error", "No message found with this id")
+		return
+	}
+
+	message := respData.Messages[0]
+	data.ApplicationId = types.StringValue(strconv.FormatInt(message.AppID, 10))
+	data.Title = types.StringValue(message.Title)
+	data.Message = types.StringValue(message.Message)
+	data.Priority = NewPriorityValueInt64(message.Priority)
+	data.Date = types.StringValue(message.Date)
+	data.Found = types.BoolValue(true)
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMessageDataSource(t *testing.T) {
+	// The message is sent with the token of an application created by the
+	// first step, and deleted with it.
+	var token string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + testAccMessageDataSourceApplicationConfig,
+				Check: resource.TestCheckResourceAttrWith("gotify_application.test", "token", func(value string) error {
+					token = value
+					return nil
+				}),
+			},
+			// Read testing
+			{
+				PreConfig: func() { testAccSendMessage(t, token, "Backup done") },
+				Config:    testAccProviderConfig() + testAccMessageDataSourceApplicationConfig + testAccMessageDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.gotify_message.test", "id", "data.gotify_messages.test", "messages.0.id"),
+					resource.TestCheckResourceAttrPair("data.gotify_message.test", "application_id", "gotify_application.test", "id"),
+					resource.TestCheckResourceAttr("data.gotify_message.test", "message", "Backup done"),
+					resource.TestCheckResourceAttrSet("data.gotify_message.test", "date"),
+				),
+			},
+		},
+	})
+}
+
+// testAccSendMessage sends message to Gotify with the application token.
+func testAccSendMessage(t *testing.T, token string, message string) {
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(os.Getenv("GOTIFY_URL"), "/")+"/message", strings.NewReader(fmt.Sprintf(`{"message":%q}`, message)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gotify-Key", token)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("can't send the message: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("can't send the message: %s", res.Status)
+	}
+}
+
+const testAccMessageDataSourceApplicationConfig = `
+resource "gotify_application" "test" {
+  name             = "tf-acc-message"
+  description      = "Sends the acceptance test messages"
+  default_priority = "4"
+}
+`
+
+const testAccMessageDataSourceConfig = `
+data "gotify_messages" "test" {
+  application_id = gotify_application.test.id
+}
+
+data "gotify_message" "test" {
+  id = data.gotify_messages.test.messages[0].id
+}
+`
+
+func TestMessageDataSourceFailIfMissing(t *testing.T) {
+	ctx := context.Background()
+
+	fake := newFakeGotify()
+	fake.applications[1] = &fakeApplication{ID: 1, Name: "backups"}
+	fake.messages[3] = &fakeMessage{ID: 3, AppID: 1, Title: "Backup", Message: "done", Priority: 4, Date: "2024-01-31T00:00:00Z"}
+	fake.messages[5] = &fakeMessage{ID: 5, AppID: 1, Title: "Backup", Message: "failed", Priority: 8, Date: "2024-02-01T00:00:00Z"}
+
+	d := &MessageDataSource{
+		client: &GotifyClient{
+			Client: &http.Client{Transport: &handlerTransport{handler: fake}},
+			Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	tests := map[string]struct {
+		id            string
+		failIfMissing types.Bool
+		expectedFound bool
+		wantError     bool
+	}{
+		"found":           {id: "5", failIfMissing: types.BoolNull(), expectedFound: true},
+		"found, allowed":  {id: "3", failIfMissing: types.BoolValue(false), expectedFound: true},
+		"missing":         {id: "4", failIfMissing: types.BoolNull(), wantError: true},
+		"missing failing": {id: "4", failIfMissing: types.BoolValue(true), wantError: true},
+		"missing allowed": {id: "4", failIfMissing: types.BoolValue(false)},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			diags := config.Set(ctx, &MessageDataSourceModel{
+				Id:            types.StringValue(test.id),
+				ApplicationId: types.StringNull(),
+				Title:         types.StringNull(),
+				Message:       types.StringNull(),
+				Priority:      PriorityValue{StringValue: types.StringNull()},
+				Date:          types.StringNull(),
+				FailIfMissing: test.failIfMissing,
+				Found:         types.BoolNull(),
+			})
+			if diags.HasError() {
+				t.Fatalf("can't build config: %v", diags)
+			}
+
+			req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+
+			d.Read(ctx, req, resp)
+
+			if test.wantError {
+				if errs := resp.Diagnostics.Errors(); len(errs) != 1 || errs[0].Detail() != "No message found with this id" {
+					t.Fatalf("expected a missing message error, got %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			var data MessageDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			if data.Found.ValueBool() != test.expectedFound {
+				t.Fatalf("expected found to be %t, got %s", test.expectedFound, data.Found)
+			}
+			if test.expectedFound && data.Id.ValueString() != test.id {
+				t.Fatalf("expected the message %s, got %+v", test.id, data)
+			}
+			if !test.expectedFound && (!data.Title.IsNull() || !data.Message.IsNull() || !data.Date.IsNull()) {
+				t.Fatalf("expected null attributes, got %+v", data)
+			}
+		})
+	}
+}
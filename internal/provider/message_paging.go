@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// messagePageSize is the number of messages requested per page by default,
+// the maximum Gotify allows.
+const messagePageSize = 200
+
+// messageResponse is a message as answered by Gotify.
+type messageResponse struct {
+	ID       int64                  `json:"id"`
+	AppID    int64                  `json:"appid"`
+	Message  string                 `json:"message"`
+	Title    string                 `json:"title"`
+	Priority int64                  `json:"priority"`
+	Extras   map[string]interface{} `json:"extras"`
+	Date     string                 `json:"date"`
+}
+
+// validatePageSize checks the page_size attribute, between 1 and the
+// maximum Gotify allows.
+func validatePageSize(pageSize types.Int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !pageSize.IsNull() && (pageSize.ValueInt64() <= 0 || pageSize.ValueInt64() > messagePageSize) {
+		diags.AddAttributeError(path.Root("page_size"), "Invalid page_size", fmt.Sprintf("page_size must be between 1 and %d", messagePageSize))
+	}
+
+	return diags
+}
+
+// streamMessages reads the messages newest first, of the application
+// applicationId when set, one page at a time. Each page is passed to visit
+// with whether older messages remain, and visit returns the number of
+// messages to request in the next page, or 0 to stop. The pages are
+// requested with the since cursor of the previous one and aren't kept, so
+// reading a large history doesn't hold it in memory.
+func (c *GotifyClient) streamMessages(ctx context.Context, applicationId types.String, pageSize int64, visit func(messages []messageResponse, more bool) (int64, diag.Diagnostics)) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	url := strings.Trim(c.Config.Url.String(), "\"")
+
+	// Messages of a single application are listed by their own endpoint,
+	// paged the same way.
+	listUrl := url + "/message"
+	if !applicationId.IsNull() {
+		listUrl = fmt.Sprintf("%s/application/%s/message", url, applicationId.ValueString())
+	}
+
+	type JsonReponse struct {
+		Messages []messageResponse `json:"messages"`
+		Paging   struct {
+			Limit int64  `json:"limit"`
+			Next  string `json:"next"`
+			Since int64  `json:"since"`
+			Size  int64  `json:"size"`
+		} `json:"paging"`
+	}
+
+	var since int64
+
+	for pageSize > 0 {
+		pageUrl := fmt.Sprintf("%s?limit=%d", listUrl, pageSize)
+		if since > 0 {
+			pageUrl = fmt.Sprintf("%s&since=%d", pageUrl, since)
+		}
+
+		httpReq, err := http.NewRequestWithContext(ctx, "GET", pageUrl, nil)
+		if err != nil {
+			tflog.Error(ctx, err.Error())
+			diags.AddError("Can't send request to Gotify", err.Error())
+			return diags
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+
+		httpRes, err := c.Do(httpReq)
+		if err != nil {
+			tflog.Error(ctx, err.Error())
+			c.addRequestError(&diags, err)
+			return diags
+		}
+
+		if err := checkResponse(httpRes); errors.Is(err, ErrNotFound) && !applicationId.IsNull() {
+			httpRes.Body.Close()
+			diags.AddError("Application not found", fmt.Sprintf("No application found with the id %s", applicationId.ValueString()))
+			return diags
+		} else if err != nil {
+			c.addResponseError(&diags, err)
+			httpRes.Body.Close()
+			return diags
+		}
+
+		var respData JsonReponse
+
+		err = c.decode(httpRes.Body, &respData)
+		httpRes.Body.Close()
+		if err != nil {
+			diags.AddError("API Error when contacting Gotify instance", err.Error())
+			return diags
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Read a page of %d messages", len(respData.Messages)))
+
+		more := respData.Paging.Next != "" && len(respData.Messages) > 0
+
+		next, visitDiags := visit(respData.Messages, more)
+		diags.Append(visitDiags...)
+		if diags.HasError() || !more {
+			break
+		}
+
+		pageSize = next
+		since = respData.Paging.Since
+	}
+
+	return diags
+}
@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MessageStatsDataSource{}
+
+func NewMessageStatsDataSource() datasource.DataSource {
+	return &MessageStatsDataSource{}
+}
+
+// MessageStatsDataSource defines the data source implementation.
+type MessageStatsDataSource struct {
+	client *GotifyClient
+}
+
+// MessageStatsDataSourceModel describes the data source data model.
+type MessageStatsDataSourceModel struct {
+	Id           types.String                   `tfsdk:"id"`
+	PageSize     types.Int64                    `tfsdk:"page_size"`
+	TotalCount   types.Int64                    `tfsdk:"total_count"`
+	Applications []ApplicationMessageStatsModel `tfsdk:"applications"`
+}
+
+// ApplicationMessageStatsModel describes the statistics of one application.
+type ApplicationMessageStatsModel struct {
+	ApplicationId types.String `tfsdk:"application_id"`
+	MessageCount  types.Int64  `tfsdk:"message_count"`
+	LatestDate    types.String `tfsdk:"latest_date"`
+}
+
+func (d *MessageStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_message_stats"
+}
+
+func (d *MessageStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Message statistics per application, computed from every message of the Gotify instance",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Placeholder identifier",
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Number of messages requested per page, between 1 and %d. Only one page is held in memory at a time. Defaults to %d", messagePageSize, messagePageSize),
+			},
+			"total_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of messages of all applications",
+			},
+			"applications": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Statistics of the applications which sent at least one message, ordered by application identifier",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"application_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Application identifier",
+						},
+						"message_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Number of messages sent by the application",
+						},
+						"latest_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Date of the latest message sent by the application",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *MessageStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*GotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *GotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *MessageStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MessageStatsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	resp.Diagnostics.Append(validatePageSize(data.PageSize)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := d.client.operationContext(ctx, "read", nil)
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	pageSize := int64(messagePageSize)
+	if !data.PageSize.IsNull() {
+		pageSize = data.PageSize.ValueInt64()
+	}
+
+	stats := map[int64]*ApplicationMessageStatsModel{}
+	var total int64
+
+	// Messages are returned newest first, one page at a time, so the first
+	// message seen for an application is its latest one.
+	resp.Diagnostics.Append(d.client.streamMessages(ctx, types.StringNull(), pageSize, func(messages []messageResponse, more bool) (int64, diag.Diagnostics) {
+		for _, message := range messages {
+			total++
+
+			stat, ok := stats[message.AppID]
+			if !ok {
+				stat = &ApplicationMessageStatsModel{
+					ApplicationId: types.StringValue(strconv.FormatInt(message.AppID, 10)),
+					MessageCount:  types.Int64Value(0),
+					LatestDate:    types.StringValue(message.Date),
+				}
+				stats[message.AppID] = stat
+			}
+			stat.MessageCount = types.Int64Value(stat.MessageCount.ValueInt64() + 1)
+		}
+
+		return pageSize, nil
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appIds := make([]int64, 0, len(stats))
+	for appId := range stats {
+		appIds = append(appIds, appId)
+	}
+	sort.Slice(appIds, func(i, j int) bool { return appIds[i] < appIds[j] })
+
+	data.Applications = make([]ApplicationMessageStatsModel, 0, len(appIds))
+	for _, appId := range appIds {
+		data.Applications = append(data.Applications, *stats[appId])
+	}
+
+	data.Id = types.StringValue("message_stats")
+	data.TotalCount = types.Int64Value(total)
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMessageStatsDataSource(t *testing.T) {
+	testAccVCR(t, "message_stats_data_source")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccProviderConfig() + testAccMessageStatsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.gotify_message_stats.test", "total_count"),
+					resource.TestCheckResourceAttrSet("data.gotify_message_stats.test", "applications.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccMessageStatsDataSourceConfig = `
+data "gotify_message_stats" "test" {}
+`
+
+func TestMessageStatsDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	fake := newFakeGotify()
+	for i := int64(1); i <= 5; i++ {
+		fake.messages[i] = &fakeMessage{ID: i, AppID: 1 + i%2, Message: "message", Date: fmt.Sprintf("2024-01-0%dT00:00:00Z", i)}
+	}
+
+	// The limits of the pages requested are recorded.
+	var pages []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages = append(pages, r.URL.Query().Get("limit"))
+		fake.ServeHTTP(w, r)
+	})
+
+	d := &MessageStatsDataSource{
+		client: &GotifyClient{
+			Client: &http.Client{Transport: &handlerTransport{handler: handler}},
+			Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	config := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := config.Set(ctx, &MessageStatsDataSourceModel{
+		Id:         types.StringNull(),
+		PageSize:   types.Int64Value(2),
+		TotalCount: types.Int64Null(),
+	})
+	if diags.HasError() {
+		t.Fatalf("can't build config: %v", diags)
+	}
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+
+	d.Read(ctx, req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if strings.Join(pages, ",") != "2,2,2" {
+		t.Fatalf("expected pages of 2 messages, got %v", pages)
+	}
+
+	var data MessageStatsDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+
+	if data.TotalCount.ValueInt64() != 5 || len(data.Applications) != 2 {
+		t.Fatalf("expected 5 messages of 2 applications, got %+v", data)
+	}
+
+	// The first message seen of each application is its latest one.
+	for i, expected := range []struct {
+		count int64
+		date  string
+	}{{count: 2, date: "2024-01-04T00:00:00Z"}, {count: 3, date: "2024-01-05T00:00:00Z"}} {
+		if stat := data.Applications[i]; stat.MessageCount.ValueInt64() != expected.count || stat.LatestDate.ValueString() != expected.date {
+			t.Fatalf("unexpected statistics of application %d: %+v", i+1, stat)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultMessagesLimit is the number of messages listed when limit isn't
+// set.
+const defaultMessagesLimit = 1000
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MessagesDataSource{}
+
+func NewMessagesDataSource() datasource.DataSource {
+	return &MessagesDataSource{}
+}
+
+// MessagesDataSource defines the data source implementation.
+type MessagesDataSource struct {
+	client *GotifyClient
+}
+
+// MessagesDataSourceModel describes the data source data model.
+type MessagesDataSourceModel struct {
+	Id            types.String   `tfsdk:"id"`
+	ApplicationId types.String   `tfsdk:"application_id"`
+	SinceTime     types.String   `tfsdk:"since_time"`
+	Limit         types.Int64    `tfsdk:"limit"`
+	PageSize      types.Int64    `tfsdk:"page_size"`
+	Messages      []MessageModel `tfsdk:"messages"`
+}
+
+// MessageModel describes a message listed by the data source.
+type MessageModel struct {
+	Id            types.String  `tfsdk:"id"`
+	ApplicationId types.String  `tfsdk:"application_id"`
+	Title         types.String  `tfsdk:"title"`
+	Message       types.String  `tfsdk:"message"`
+	Priority      PriorityValue `tfsdk:"priority"`
+	Date          types.String  `tfsdk:"date"`
+
+	ApplicationName  types.String `tfsdk:"application_name"`
+	ApplicationImage types.String `tfsdk:"application_image"`
+}
+
+func (d *MessagesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_messages"
+}
+
+func (d *MessagesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Messages data source",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Placeholder identifier",
+			},
+			"application_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the messages sent by this application",
+			},
+			"since_time": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the messages sent at or after this RFC3339 date, e.g. `2024-01-31T00:00:00Z`",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum number of messages listed, newest first. Paging stops once it is reached, so the state doesn't hold the whole history. Defaults to %d, with a warning when older messages weren't listed", defaultMessagesLimit),
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Number of messages requested per page, between 1 and %d. Defaults to %d", messagePageSize, messagePageSize),
+			},
+			"messages": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Messages, newest first",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Message identifier",
+						},
+						"application_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Identifier of the application which sent the message",
+						},
+						"title": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Title of the message",
+						},
+						"message": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Content of the message",
+						},
+						"priority": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Priority of the message",
+							CustomType:          PriorityType{},
+						},
+						"date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Date the message was sent at",
+						},
+						"application_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the application which sent the message",
+						},
+						"application_image": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "URL of the image of the application which sent the message",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *MessagesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*GotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *GotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *MessagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MessagesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validatePage(types.Int64Null(), data.Limit)...)
+	resp.Diagnostics.Append(validatePageSize(data.PageSize)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var sinceTime time.Time
+	if !data.SinceTime.IsNull() {
+		var err error
+		sinceTime, err = time.Parse(time.RFC3339, data.SinceTime.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("since_time"), "Invalid since_time", err.Error())
+			return
+		}
+	}
+
+	ctx, cancel := d.client.operationContext(ctx, "read", nil)
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	url := strings.Trim(d.client.Config.Url.String(), "\"")
+
+	pageSize := int64(messagePageSize)
+	if !data.PageSize.IsNull() {
+		pageSize = data.PageSize.ValueInt64()
+	}
+
+	limit := int64(defaultMessagesLimit)
+	if !data.Limit.IsNull() {
+		limit = data.Limit.ValueInt64()
+	}
+
+	// The last page only requests the messages missing to reach limit.
+	nextPageSize := func() int64 {
+		if remaining := limit - int64(len(data.Messages)); remaining < pageSize {
+			return remaining
+		}
+		return pageSize
+	}
+
+	data.Messages = []MessageModel{}
+	truncated := false
+
+	// Messages are returned newest first, so paging stops at the first
+	// message older than since_time, or once limit messages are listed.
+	resp.Diagnostics.Append(d.client.streamMessages(ctx, data.ApplicationId, nextPageSize(), func(messages []messageResponse, more bool) (int64, diag.Diagnostics) {
+		var diags diag.Diagnostics
+
+		for _, message := range messages {
+			if !sinceTime.IsZero() {
+				date, err := time.Parse(time.RFC3339Nano, message.Date)
+				if err != nil {
+					diags.AddError("API Error when contacting Gotify instance", fmt.Sprintf("Can't parse the date of message %d: %s", message.ID, err))
+					return 0, diags
+				}
+
+				if date.Before(sinceTime) {
+					return 0, diags
+				}
+			}
+
+			data.Messages = append(data.Messages, MessageModel{
+				Id:            types.StringValue(strconv.FormatInt(message.ID, 10)),
+				ApplicationId: types.StringValue(strconv.FormatInt(message.AppID, 10)),
+				Title:         types.StringValue(message.Title),
+				Message:       types.StringValue(message.Message),
+				Priority:      NewPriorityValueInt64(message.Priority),
+				Date:          types.StringValue(message.Date),
+			})
+		}
+
+		truncated = more && int64(len(data.Messages)) >= limit
+
+		return nextPageSize(), diags
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if truncated && data.Limit.IsNull() {
+		resp.Diagnostics.AddWarning(
+			"Messages truncated",
+			fmt.Sprintf("Only the latest %d messages were listed, older ones weren't read. Set limit to list more of them, or since_time to only list the recent ones.", defaultMessagesLimit),
+		)
+	}
+
+	// The applications are joined once, instead of a lookup per message.
+	if len(data.Messages) > 0 {
+		applications, err := d.client.listApplications(ctx)
+		if err != nil {
+			tflog.Error(ctx, err.Error())
+			resp.Diagnostics.AddError("Can't list the applications which sent the messages", err.Error())
+			return
+		}
+
+		joinApplications(data.Messages, applications, url)
+	}
+
+	data.Id = types.StringValue("messages")
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// joinApplications sets the name and image of the application which sent
+// each message of messages, among applications. They are null for the
+// messages of applications which don't exist anymore.
+func joinApplications(messages []MessageModel, applications []applicationResponse, url string) {
+	byID := map[string]applicationResponse{}
+	for _, application := range applications {
+		byID[strconv.FormatInt(application.ID, 10)] = application
+	}
+
+	for i := range messages {
+		application, ok := byID[messages[i].ApplicationId.ValueString()]
+		if !ok {
+			messages[i].ApplicationName = types.StringNull()
+			messages[i].ApplicationImage = types.StringNull()
+			continue
+		}
+
+		messages[i].ApplicationName = types.StringValue(application.Name)
+		messages[i].ApplicationImage = types.StringValue(url + "/" + application.Image)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMessagesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccProviderConfig() + testAccMessagesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gotify_messages.test", "messages.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccMessagesDataSourceConfig = `
+data "gotify_messages" "test" {
+  since_time = "2999-01-01T00:00:00Z"
+}
+`
+
+func TestMessagesDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	fake := newFakeGotify()
+	fake.applications[1] = &fakeApplication{ID: 1, Name: "backups", Image: "image/backups.png"}
+	fake.applications[2] = &fakeApplication{ID: 2, Name: "alerts", Image: "image/alerts.png"}
+	fake.nextID = 1000
+
+	// More messages than a page, one per hour, alternating applications.
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := int64(1); i <= 300; i++ {
+		fake.messages[i] = &fakeMessage{ID: i, AppID: 1 + i%2, Message: "message", Date: start.Add(time.Duration(i) * time.Hour).Format(time.RFC3339Nano)}
+	}
+
+	d := &MessagesDataSource{
+		client: &GotifyClient{
+			Client: &http.Client{Transport: &handlerTransport{handler: fake}},
+			Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	tests := map[string]struct {
+		applicationId types.String
+		sinceTime     types.String
+		limit         types.Int64
+		expected      int
+		newest        string
+		sender        string
+	}{
+		"all":              {applicationId: types.StringNull(), sinceTime: types.StringNull(), limit: types.Int64Null(), expected: 300, newest: "300", sender: "backups"},
+		"since time":       {applicationId: types.StringNull(), sinceTime: types.StringValue(start.Add(51 * time.Hour).Format(time.RFC3339)), limit: types.Int64Null(), expected: 250, newest: "300", sender: "backups"},
+		"application":      {applicationId: types.StringValue("2"), sinceTime: types.StringNull(), limit: types.Int64Null(), expected: 150, newest: "299", sender: "alerts"},
+		"application time": {applicationId: types.StringValue("2"), sinceTime: types.StringValue(start.Add(51 * time.Hour).Format(time.RFC3339)), limit: types.Int64Null(), expected: 125, newest: "299", sender: "alerts"},
+		"limit":            {applicationId: types.StringNull(), sinceTime: types.StringNull(), limit: types.Int64Value(250), expected: 250, newest: "300", sender: "backups"},
+		"limit since time": {applicationId: types.StringNull(), sinceTime: types.StringValue(start.Add(251 * time.Hour).Format(time.RFC3339)), limit: types.Int64Value(100), expected: 50, newest: "300", sender: "backups"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			diags := config.Set(ctx, &MessagesDataSourceModel{
+				Id:            types.StringNull(),
+				ApplicationId: test.applicationId,
+				SinceTime:     test.sinceTime,
+				Limit:         test.limit,
+			})
+			if diags.HasError() {
+				t.Fatalf("can't build config: %v", diags)
+			}
+
+			req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+
+			d.Read(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			var data MessagesDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+
+			if len(data.Messages) != test.expected || data.Messages[0].Id.ValueString() != test.newest {
+				t.Fatalf("expected %d messages starting with %s, got %d", test.expected, test.newest, len(data.Messages))
+			}
+
+			if data.Messages[0].ApplicationName.ValueString() != test.sender || data.Messages[0].ApplicationImage.ValueString() != mockUrl+"/image/"+test.sender+".png" {
+				t.Fatalf("expected the newest message to be sent by %s, got %s with image %s", test.sender, data.Messages[0].ApplicationName, data.Messages[0].ApplicationImage)
+			}
+		})
+	}
+}
+
+func TestMessagesDataSourceReadLimit(t *testing.T) {
+	ctx := context.Background()
+
+	fake := newFakeGotify()
+	fake.applications[1] = &fakeApplication{ID: 1, Name: "backups", Image: "image/backups.png"}
+	for i := int64(1); i <= 1200; i++ {
+		fake.messages[i] = &fakeMessage{ID: i, AppID: 1, Message: "message", Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(i) * time.Hour).Format(time.RFC3339Nano)}
+	}
+
+	// The limits of the pages requested are recorded.
+	var pages []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/message" {
+			pages = append(pages, r.URL.Query().Get("limit"))
+		}
+		fake.ServeHTTP(w, r)
+	})
+
+	d := &MessagesDataSource{
+		client: &GotifyClient{
+			Client: &http.Client{Transport: &handlerTransport{handler: handler}},
+			Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	tests := map[string]struct {
+		limit        types.Int64
+		pageSize     types.Int64
+		expected     []string
+		wantMessages int
+		wantWarning  bool
+		wantError    bool
+	}{
+		"within a page":      {limit: types.Int64Value(10), expected: []string{"10"}, wantMessages: 10},
+		"a few pages":        {limit: types.Int64Value(450), expected: []string{"200", "200", "50"}, wantMessages: 450},
+		"smaller pages":      {limit: types.Int64Value(250), pageSize: types.Int64Value(100), expected: []string{"100", "100", "50"}, wantMessages: 250},
+		"whole history":      {limit: types.Int64Value(5000), expected: []string{"200", "200", "200", "200", "200", "200"}, wantMessages: 1200},
+		"default limit":      {expected: []string{"200", "200", "200", "200", "200"}, wantMessages: defaultMessagesLimit, wantWarning: true},
+		"negative":           {limit: types.Int64Value(-1), wantError: true},
+		"page size too high": {pageSize: types.Int64Value(201), wantError: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pages = nil
+
+			config := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			diags := config.Set(ctx, &MessagesDataSourceModel{
+				Id:            types.StringNull(),
+				ApplicationId: types.StringNull(),
+				SinceTime:     types.StringNull(),
+				Limit:         test.limit,
+				PageSize:      test.pageSize,
+			})
+			if diags.HasError() {
+				t.Fatalf("can't build config: %v", diags)
+			}
+
+			req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+
+			d.Read(ctx, req, resp)
+
+			if test.wantError {
+				if !resp.Diagnostics.HasError() || len(pages) != 0 {
+					t.Fatalf("expected an error before any request, got %v after %d pages", resp.Diagnostics, len(pages))
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			// Older messages are only left out silently when a limit is set.
+			if warned := resp.Diagnostics.WarningsCount() == 1; warned != test.wantWarning {
+				t.Fatalf("expected a truncation warning: %t, got %v", test.wantWarning, resp.Diagnostics)
+			}
+
+			var data MessagesDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+
+			if len(data.Messages) != test.wantMessages || data.Messages[0].Id.ValueString() != "1200" {
+				t.Fatalf("expected the %d newest messages, got %d", test.wantMessages, len(data.Messages))
+			}
+			if strings.Join(pages, ",") != strings.Join(test.expected, ",") {
+				t.Fatalf("expected pages of %v messages, got %v", test.expected, pages)
+			}
+		})
+	}
+}
+
+func TestJoinApplications(t *testing.T) {
+	messages := []MessageModel{
+		{ApplicationId: types.StringValue("1")},
+		{ApplicationId: types.StringValue("9")},
+	}
+
+	joinApplications(messages, []applicationResponse{{ID: 1, Name: "backups", Image: "static/defaultapp.png"}}, "https://gotify.example.com")
+
+	if messages[0].ApplicationName.ValueString() != "backups" || messages[0].ApplicationImage.ValueString() != "https://gotify.example.com/static/defaultapp.png" {
+		t.Fatalf("unexpected application of the first message: %s, %s", messages[0].ApplicationName, messages[0].ApplicationImage)
+	}
+
+	// The messages of deleted applications have no application to join.
+	if !messages[1].ApplicationName.IsNull() || !messages[1].ApplicationImage.IsNull() {
+		t.Fatalf("expected no application for the second message, got %s, %s", messages[1].ApplicationName, messages[1].ApplicationImage)
+	}
+}
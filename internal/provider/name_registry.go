@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"sync"
+)
+
+// nameRegistry records the names of the applications planned by a
+// configured provider. Terraform plans every resource instance once per run
+// with the same configured provider, so a name registered twice belongs to
+// two resources of the configuration. A replaced application is the
+// exception: Terraform plans it a second time as a new application.
+type nameRegistry struct {
+	mu        sync.Mutex
+	names     map[string]string
+	replacing map[string]bool
+}
+
+func newNameRegistry() *nameRegistry {
+	return &nameRegistry{names: map[string]string{}, replacing: map[string]bool{}}
+}
+
+// register records that the application described by owner is planned with
+// the name identified by key, returning the description of the application which already was,
+// if any.
+func (r *nameRegistry) register(key string, owner string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if previous, ok := r.names[key]; ok {
+		return previous, true
+	}
+
+	r.names[key] = owner
+
+	return "", false
+}
+
+// replace records that the application registered with key is replaced, so
+// the next new application planned with that name is the replacement.
+func (r *nameRegistry) replace(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.replacing[key] = true
+}
+
+// replacement reports whether a new application planned with the name
+// identified by key is the replacement of an application registered before,
+// which it can only be once.
+func (r *nameRegistry) replacement(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.replacing[key] {
+		return false
+	}
+
+	delete(r.replacing, key)
+
+	return true
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// parsePushUrl splits a URL built by pushUrl into the URL of the Gotify
+// instance and the application token.
+func parsePushUrl(rawUrl string) (string, string, error) {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", "", err
+	}
+
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return "", "", fmt.Errorf("%q isn't an absolute URL", rawUrl)
+	}
+
+	path := strings.TrimSuffix(parsed.Path, "/")
+	if !strings.HasSuffix(path, "/message") {
+		return "", "", fmt.Errorf("the path of %q doesn't end with /message", rawUrl)
+	}
+
+	token := parsed.Query().Get("token")
+	if token == "" {
+		return "", "", fmt.Errorf("%q has no token query parameter", rawUrl)
+	}
+
+	base := url.URL{
+		Scheme: parsed.Scheme,
+		User:   parsed.User,
+		Host:   parsed.Host,
+		Path:   strings.TrimSuffix(path, "/message"),
+	}
+
+	return base.String(), token, nil
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ParsePushUrlFunction{}
+
+func NewParsePushUrlFunction() function.Function {
+	return &ParsePushUrlFunction{}
+}
+
+// ParsePushUrlFunction defines the function implementation.
+type ParsePushUrlFunction struct{}
+
+// PushUrlModel describes the components of a push URL.
+type PushUrlModel struct {
+	BaseUrl types.String `tfsdk:"base_url"`
+	Token   types.String `tfsdk:"token"`
+}
+
+func (f *ParsePushUrlFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_push_url"
+}
+
+func (f *ParsePushUrlFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Split a Gotify push URL",
+		MarkdownDescription: "Splits a push URL such as `https://gotify.example.com/message?token=AToken` into the URL of the Gotify instance (`base_url`) and the application token (`token`).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "Push URL, e.g. the `push_url` of the `gotify_applications` data source",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"base_url": types.StringType,
+				"token":    types.StringType,
+			},
+		},
+	}
+}
+
+func (f *ParsePushUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rawUrl string
+
+	resp.Diagnostics.Append(req.Arguments.Get(ctx, &rawUrl)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	baseUrl, token, err := parsePushUrl(rawUrl)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid push URL", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, PushUrlModel{
+		BaseUrl: types.StringValue(baseUrl),
+		Token:   types.StringValue(token),
+	})...)
+}
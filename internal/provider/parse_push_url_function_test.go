@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParsePushUrl(t *testing.T) {
+	tests := map[string]struct {
+		url       string
+		wantBase  string
+		wantToken string
+		wantErr   bool
+	}{
+		"instance":      {url: "https://gotify.example.com/message?token=AToken", wantBase: "https://gotify.example.com", wantToken: "AToken"},
+		"sub path":      {url: "https://example.com/gotify/message/?token=AToken", wantBase: "https://example.com/gotify", wantToken: "AToken"},
+		"round trip":    {url: pushUrl("http://localhost:8080", "AToken"), wantBase: "http://localhost:8080", wantToken: "AToken"},
+		"missing token": {url: "https://gotify.example.com/message", wantErr: true},
+		"other path":    {url: "https://gotify.example.com/application?token=AToken", wantErr: true},
+		"relative":      {url: "/message?token=AToken", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			base, token, err := parsePushUrl(test.url)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %s and %s", base, token)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if base != test.wantBase || token != test.wantToken {
+				t.Fatalf("expected %s and %s, got %s and %s", test.wantBase, test.wantToken, base, token)
+			}
+		})
+	}
+}
+
+func TestParsePushUrlFunctionRun(t *testing.T) {
+	attributeTypes := map[string]attr.Type{
+		"base_url": types.StringType,
+		"token":    types.StringType,
+	}
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("https://gotify.example.com/message?token=AToken")}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ObjectUnknown(attributeTypes)),
+	}
+
+	NewParsePushUrlFunction().Run(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	expected := types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+		"base_url": types.StringValue("https://gotify.example.com"),
+		"token":    types.StringValue("AToken"),
+	})
+	if !resp.Result.Value().Equal(expected) {
+		t.Fatalf("expected %s, got %s", expected, resp.Result.Value())
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PluginConfigDataSource{}
+
+func NewPluginConfigDataSource() datasource.DataSource {
+	return &PluginConfigDataSource{}
+}
+
+// PluginConfigDataSource defines the data source implementation.
+type PluginConfigDataSource struct {
+	client *GotifyClient
+}
+
+// PluginConfigDataSourceModel describes the data source data model.
+type PluginConfigDataSourceModel struct {
+	Id     types.String `tfsdk:"id"`
+	Config types.String `tfsdk:"config"`
+}
+
+func (d *PluginConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plugin_config"
+}
+
+func (d *PluginConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Current configuration of a plugin, read without managing it",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Plugin identifier",
+			},
+			"config": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Configuration of the plugin, as YAML. Decode it with `yamldecode`",
+			},
+		},
+	}
+}
+
+func (d *PluginConfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*GotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *GotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PluginConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PluginConfigDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := d.client.operationContext(ctx, "read", nil)
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	config, diags := d.client.pluginConfig(ctx, data.Id.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Config = types.StringValue(config)
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// pluginConfig returns the YAML configuration of the plugin identified by id.
+func (c *GotifyClient) pluginConfig(ctx context.Context, id string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	url := strings.Trim(c.Config.Url.String(), "\"")
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/plugin/%s/config", url, id), nil)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		diags.AddError("Can't send request to Gotify", err.Error())
+		return "", diags
+	}
+
+	httpRes, err := c.Do(httpReq)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		c.addRequestError(&diags, err)
+		return "", diags
+	}
+	defer httpRes.Body.Close()
+
+	if err := checkResponse(httpRes); errors.Is(err, ErrNotFound) {
+		diags.AddError("Plugin not found", fmt.Sprintf("No plugin found with the id %s", id))
+		return "", diags
+	} else if err != nil {
+		c.addResponseError(&diags, err)
+		return "", diags
+	}
+
+	config, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		diags.AddError("API Error when contacting Gotify instance", err.Error())
+		return "", diags
+	}
+
+	return string(config), diags
+}
@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPluginConfigDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config:      testAccProviderConfig() + testAccPluginConfigDataSourceConfig,
+				ExpectError: regexp.MustCompile("Plugin not found"),
+			},
+		},
+	})
+}
+
+const testAccPluginConfigDataSourceConfig = `
+data "gotify_plugin_config" "test" {
+  id = "999999"
+}
+`
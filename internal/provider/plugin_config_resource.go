@@ -0,0 +1,415 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PluginConfigResource{}
+var _ resource.ResourceWithImportState = &PluginConfigResource{}
+var _ resource.ResourceWithValidateConfig = &PluginConfigResource{}
+
+func NewPluginConfigResource() resource.Resource {
+	return &PluginConfigResource{}
+}
+
+// PluginConfigResource defines the resource implementation.
+type PluginConfigResource struct {
+	client *GotifyClient
+}
+
+// PluginConfigResourceModel describes the resource data model.
+type PluginConfigResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	ModulePath    types.String `tfsdk:"module_path"`
+	Config        types.String `tfsdk:"config"`
+	AppliedConfig types.String `tfsdk:"applied_config"`
+	StrictKeys    types.Bool   `tfsdk:"strict_keys"`
+
+	Timeouts *RequestTimeoutsModel `tfsdk:"timeouts"`
+}
+
+func (r *PluginConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plugin_config"
+}
+
+func (r *PluginConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "YAML configuration of a plugin installed on the Gotify server, such as the webhook plugin. Gotify only configures disabled plugins, so an enabled plugin is disabled while its configuration is applied, then enabled again. Changes made outside of Terraform are detected on refresh and reverted by the next apply; destroying the resource leaves the configuration as it is. Import it with the module path of the plugin.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Plugin identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"module_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Module path of the plugin, e.g. `github.com/gotify/plugin-webhook`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"config": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Configuration of the plugin, as YAML. Render an HCL map with `yamlencode`",
+			},
+			"strict_keys": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether top-level keys of `config` missing from the current configuration of the plugin are errors rather than warnings. Gotify silently drops the keys a plugin doesn't know, e.g. misspelled ones. Defaults to `false`",
+			},
+			"applied_config": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Configuration as answered by Gotify once applied, in its own formatting. Refreshes compare it to the configuration of the plugin to detect changes made outside of Terraform",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": requestTimeoutsBlock("the configuration of the plugin"),
+		},
+	}
+}
+
+func (r *PluginConfigResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data PluginConfigResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateTimeouts(data.Timeouts.timeouts(), path.Root("timeouts"))...)
+}
+
+func (r *PluginConfigResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*GotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *GotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PluginConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PluginConfigResourceModel
+
+	resp.Diagnostics.Append(r.client.checkReadOnly("create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.client.operationContext(ctx, "create", data.Timeouts.timeouts())
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	unlock := r.client.pluginLocks.lock(data.ModulePath.ValueString())
+	defer unlock()
+
+	plugin, diags := r.client.findPlugin(ctx, data.ModulePath.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plugin == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("module_path"),
+			"Plugin not installed",
+			fmt.Sprintf("No plugin with the module path %s is installed on the Gotify server. Plugins are installed by adding them to the plugin directory of the server.", data.ModulePath.ValueString()),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(strconv.FormatInt(plugin.ID, 10))
+
+	resp.Diagnostics.Append(r.applyConfig(ctx, &data, plugin.Enabled)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PluginConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PluginConfigResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.client.operationContext(ctx, "read", data.Timeouts.timeouts())
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	plugin, diags := r.client.findPlugin(ctx, data.ModulePath.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plugin == nil {
+		tflog.Warn(ctx, fmt.Sprintf("Plugin %s not found, removing its configuration from the state", data.ModulePath.ValueString()))
+		if !data.Id.IsNull() {
+			resp.Diagnostics.Append(r.client.notifyDrift(ctx, fmt.Sprintf("gotify_plugin_config %s was uninstalled outside of Terraform", data.ModulePath.ValueString()))...)
+		}
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.StringValue(strconv.FormatInt(plugin.ID, 10))
+
+	// Imported configurations have no strict_keys yet.
+	if data.StrictKeys.IsNull() {
+		data.StrictKeys = types.BoolValue(false)
+	}
+
+	config, diags := r.client.pluginConfig(ctx, data.Id.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Gotify answers the configuration in its own formatting, so it is
+	// compared to the one answered once applied rather than to config.
+	// Imported configurations have no prior value to drift from.
+	if data.AppliedConfig.ValueString() != config {
+		if !data.AppliedConfig.IsNull() {
+			resp.Diagnostics.Append(r.client.notifyDrift(ctx, fmt.Sprintf("gotify_plugin_config %s changed outside of Terraform: config", data.ModulePath.ValueString()))...)
+		}
+
+		data.Config = types.StringValue(config)
+		data.AppliedConfig = types.StringValue(config)
+	}
+
+	tflog.Trace(ctx, "read a resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PluginConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PluginConfigResourceModel
+
+	resp.Diagnostics.Append(r.client.checkReadOnly("update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.client.operationContext(ctx, "update", data.Timeouts.timeouts())
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	unlock := r.client.pluginLocks.lock(data.ModulePath.ValueString())
+	defer unlock()
+
+	plugin, diags := r.client.findPlugin(ctx, data.ModulePath.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plugin == nil {
+		resp.Diagnostics.AddError("Plugin not found", fmt.Sprintf("No plugin with the module path %s is installed on the Gotify server anymore", data.ModulePath.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(r.applyConfig(ctx, &data, plugin.Enabled)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only removes the configuration from the state: plugins always have
+// one, and there is no previous configuration to restore.
+func (r *PluginConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PluginConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("The configuration of plugin %s is left as it is, only removing it from the state", data.ModulePath.ValueString()))
+}
+
+func (r *PluginConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("module_path"), req, resp)
+}
+
+// applyConfig sends the config of data to the plugin identified by its id,
+// and sets its applied_config to the configuration Gotify answers back. Its
+// top-level keys are first checked against the current configuration.
+// Gotify only configures disabled plugins, so an enabled one is disabled
+// for the configuration to be sent, then enabled again.
+func (r *PluginConfigResource) applyConfig(ctx context.Context, data *PluginConfigResourceModel, enabled bool) (diags diag.Diagnostics) {
+	url := strings.Trim(r.client.Config.Url.String(), "\"")
+	id := data.Id.ValueString()
+
+	current, configDiags := r.client.pluginConfig(ctx, id)
+	diags.Append(configDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if unknown := unknownConfigKeys(data.Config.ValueString(), current); len(unknown) > 0 {
+		summary := "Unknown plugin configuration keys"
+		detail := fmt.Sprintf("The current configuration of plugin %s has no %s key, Gotify drops the keys the plugin doesn't know. Check them for typos.", data.ModulePath.ValueString(), strings.Join(unknown, ", "))
+		if data.StrictKeys.ValueBool() {
+			diags.AddAttributeError(path.Root("config"), summary, detail)
+			return diags
+		}
+		diags.AddAttributeWarning(path.Root("config"), summary, detail)
+	}
+
+	if enabled {
+		pluginID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			diags.AddError("Invalid plugin id", fmt.Sprintf("The id %q of the plugin isn't a number", id))
+			return diags
+		}
+
+		diags.Append(r.client.setPluginEnabled(ctx, pluginID, false)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		// The plugin is enabled again even when the configuration is refused.
+		defer func() {
+			diags.Append(r.client.setPluginEnabled(ctx, pluginID, true)...)
+		}()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/plugin/%s/config", url, id), strings.NewReader(data.Config.ValueString()))
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		diags.AddError("Can't send request to Gotify", err.Error())
+		return diags
+	}
+	httpReq.Header.Set("Content-Type", "application/x-yaml")
+
+	httpRes, err := r.client.Do(httpReq)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		r.client.addRequestError(&diags, err)
+		return diags
+	}
+	defer httpRes.Body.Close()
+
+	if err := checkResponse(httpRes); errors.Is(err, ErrNotFound) {
+		diags.AddError("Plugin not found", fmt.Sprintf("No plugin found with the id %s", id))
+		return diags
+	} else if err != nil {
+		diags.AddAttributeError(path.Root("config"), "Configuration refused by the plugin", err.Error())
+		return diags
+	}
+
+	config, configDiags := r.client.pluginConfig(ctx, id)
+	diags.Append(configDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	data.AppliedConfig = types.StringValue(config)
+
+	return diags
+}
+
+// unknownConfigKeys returns the top-level keys of the YAML config missing
+// from current, quoted. Plugins without any configuration aren't checked.
+func unknownConfigKeys(config string, current string) []string {
+	known := yamlTopLevelKeys(current)
+	if len(known) == 0 {
+		return nil
+	}
+
+	var unknown []string
+	for _, key := range yamlTopLevelKeys(config) {
+		if !contains(known, key) {
+			unknown = append(unknown, strconv.Quote(key))
+		}
+	}
+
+	return unknown
+}
+
+// yamlTopLevelKeys returns the keys of the top-level mapping of the block
+// style YAML document s, as rendered by yamlencode or answered by Gotify.
+// Flow style mappings have no key read.
+func yamlTopLevelKeys(s string) []string {
+	var keys []string
+
+	for _, line := range strings.Split(s, "\n") {
+		// Nested values are indented, and sequences or comments aren't keys.
+		if line == "" || strings.ContainsRune(" \t-#{", rune(line[0])) {
+			continue
+		}
+
+		key, _, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		if unquoted, err := strconv.Unquote(key); err == nil {
+			key = unquoted
+		} else if len(key) >= 2 && key[0] == '\'' && key[len(key)-1] == '\'' {
+			key = strings.ReplaceAll(key[1:len(key)-1], "''", "'")
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys
+}
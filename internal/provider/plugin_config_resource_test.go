@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPluginConfigResource(t *testing.T) {
+	ctx := context.Background()
+
+	fake := newFakeGotify()
+	fake.plugins[3] = &fakePlugin{ID: 3, Name: "Webhook", ModulePath: "github.com/gotify/plugin-webhook", Config: "url: \"\"\n"}
+
+	r := &PluginConfigResource{
+		client: &GotifyClient{
+			Client: &http.Client{Transport: newTransport(GotifyProviderModel{Mock: types.BoolValue(true)}, nil, "")},
+			Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+		},
+	}
+	r.client.Transport.(*gotifyTransport).base = &handlerTransport{handler: fake}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	// The configuration is kept as configured, whatever the formatting Gotify
+	// answers it back in.
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	diags := plan.Set(ctx, &PluginConfigResourceModel{
+		Id:            types.StringUnknown(),
+		ModulePath:    types.StringValue("github.com/gotify/plugin-webhook"),
+		Config:        types.StringValue("url: https://hooks.example.com\n\n"),
+		AppliedConfig: types.StringUnknown(),
+		StrictKeys:    types.BoolValue(false),
+	})
+	if diags.HasError() {
+		t.Fatalf("can't build plan: %v", diags)
+	}
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+
+	var created PluginConfigResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(ctx, &created)...)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", createResp.Diagnostics)
+	}
+	if created.Id.ValueString() != "3" || created.AppliedConfig.ValueString() != "url: https://hooks.example.com\n" || fake.plugins[3].Config != created.AppliedConfig.ValueString() {
+		t.Fatalf("expected the configuration to be applied, got %+v", created)
+	}
+
+	read := func(state tfsdk.State) PluginConfigResourceModel {
+		resp := &fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+
+		var data PluginConfigResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+
+		return data
+	}
+
+	if data := read(createResp.State); data != created {
+		t.Fatalf("expected no change\ncreated: %+v\nread:    %+v", created, data)
+	}
+
+	// Changes made outside of Terraform are read into config, so the next
+	// plan restores it.
+	fake.plugins[3].Config = "url: https://elsewhere.example.com\n"
+	if data := read(createResp.State); data.Config.ValueString() != fake.plugins[3].Config || data.AppliedConfig.ValueString() != fake.plugins[3].Config {
+		t.Fatalf("expected the changed configuration to be read, got %+v", data)
+	}
+
+	updatePlan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	diags = updatePlan.Set(ctx, &PluginConfigResourceModel{
+		Id:            created.Id,
+		ModulePath:    created.ModulePath,
+		Config:        created.Config,
+		AppliedConfig: types.StringUnknown(),
+		StrictKeys:    types.BoolValue(false),
+	})
+	if diags.HasError() {
+		t.Fatalf("can't build plan: %v", diags)
+	}
+
+	updateResp := &fwresource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: updatePlan, State: createResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() || fake.plugins[3].Config != "url: https://hooks.example.com\n" {
+		t.Fatalf("expected the configuration to be restored, got %q, %v", fake.plugins[3].Config, updateResp.Diagnostics)
+	}
+
+	// The configuration is imported with the module path of the plugin.
+	importResp := &fwresource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "github.com/gotify/plugin-webhook"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", importResp.Diagnostics)
+	}
+	if data := read(importResp.State); data.Id.ValueString() != "3" || data.Config.ValueString() != "url: https://hooks.example.com\n" {
+		t.Fatalf("expected the configuration to be imported, got %+v", data)
+	}
+}
+
+func TestPluginConfigResourceUnknownKeys(t *testing.T) {
+	ctx := context.Background()
+
+	r := &PluginConfigResource{}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	tests := map[string]struct {
+		config      string
+		strict      bool
+		wantWarning bool
+		wantError   bool
+	}{
+		"known keys":        {config: "\"url\": \"https://hooks.example.com\"\n\"headers\":\n  \"X-Token\": \"secret\"\n"},
+		"misspelled key":    {config: "url: https://hooks.example.com\nheader: {}\n", wantWarning: true},
+		"strict misspelled": {config: "url: https://hooks.example.com\nheader: {}\n", strict: true, wantError: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake := newFakeGotify()
+			fake.plugins[3] = &fakePlugin{ID: 3, Name: "Webhook", ModulePath: "github.com/gotify/plugin-webhook", Config: "url: \"\"\nheaders: {}\n"}
+
+			r.client = &GotifyClient{
+				Client: &http.Client{Transport: &handlerTransport{handler: fake}},
+				Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+			}
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+			diags := plan.Set(ctx, &PluginConfigResourceModel{
+				Id:            types.StringUnknown(),
+				ModulePath:    types.StringValue("github.com/gotify/plugin-webhook"),
+				Config:        types.StringValue(test.config),
+				AppliedConfig: types.StringUnknown(),
+				StrictKeys:    types.BoolValue(test.strict),
+			})
+			if diags.HasError() {
+				t.Fatalf("can't build plan: %v", diags)
+			}
+
+			resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+
+			if resp.Diagnostics.HasError() != test.wantError || (resp.Diagnostics.WarningsCount() > 0) != test.wantWarning {
+				t.Fatalf("expected error %t and warning %t, got %v", test.wantError, test.wantWarning, resp.Diagnostics)
+			}
+
+			// Configurations refused for their keys aren't sent to Gotify.
+			applied := fake.plugins[3].Config != "url: \"\"\nheaders: {}\n"
+			if applied == test.wantError {
+				t.Fatalf("expected the configuration to be applied only without errors, got %q", fake.plugins[3].Config)
+			}
+		})
+	}
+}
+
+func TestYamlTopLevelKeys(t *testing.T) {
+	document := "# webhook\n---\nurl: https://hooks.example.com\n\"headers\":\n  \"X-Token\": secret\n'it''s': true\nevents:\n- push\n"
+
+	keys := yamlTopLevelKeys(document)
+	if strings.Join(keys, ",") != "url,headers,it's,events" {
+		t.Fatalf("unexpected keys: %q", keys)
+	}
+
+	if unknown := unknownConfigKeys(document, ""); unknown != nil {
+		t.Fatalf("expected plugins without configuration not to be checked, got %v", unknown)
+	}
+}
+
+func TestPluginConfigResourceEnabledPlugin(t *testing.T) {
+	ctx := context.Background()
+
+	fake := newFakeGotify()
+	fake.plugins[3] = &fakePlugin{ID: 3, Name: "Webhook", ModulePath: "github.com/gotify/plugin-webhook", Enabled: true, Config: "url: \"\"\n"}
+
+	// The calls changing the plugin are recorded.
+	var calls []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			calls = append(calls, r.URL.Path)
+		}
+		fake.ServeHTTP(w, r)
+	})
+
+	r := &PluginConfigResource{
+		client: &GotifyClient{
+			Client: &http.Client{Transport: &handlerTransport{handler: handler}},
+			Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+		},
+	}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := plan.Set(ctx, &PluginConfigResourceModel{
+		Id:            types.StringUnknown(),
+		ModulePath:    types.StringValue("github.com/gotify/plugin-webhook"),
+		Config:        types.StringValue("url: https://hooks.example.com\n"),
+		AppliedConfig: types.StringUnknown(),
+		StrictKeys:    types.BoolValue(false),
+	})
+	if diags.HasError() {
+		t.Fatalf("can't build plan: %v", diags)
+	}
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	// Gotify only configures disabled plugins.
+	expected := "/plugin/3/disable,/plugin/3/config,/plugin/3/enable"
+	if strings.Join(calls, ",") != expected || !fake.plugins[3].Enabled || fake.plugins[3].Config != "url: https://hooks.example.com\n" {
+		t.Fatalf("expected the plugin to be configured while disabled, got calls %v", calls)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"sync"
+)
+
+// pluginLocks serializes the changes made to each plugin by the resources of
+// a configured provider. Terraform applies independent resources
+// concurrently, so a gotify_plugin could otherwise enable a plugin while a
+// gotify_plugin_config has it disabled to apply its configuration.
+type pluginLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newPluginLocks() *pluginLocks {
+	return &pluginLocks{locks: map[string]*sync.Mutex{}}
+}
+
+// lock waits for the plugin installed with modulePath to be unlocked, then
+// locks it, returning the function unlocking it. Nothing is locked without
+// locks.
+func (l *pluginLocks) lock(modulePath string) func() {
+	if l == nil {
+		return func() {}
+	}
+
+	l.mu.Lock()
+	lock, ok := l.locks[modulePath]
+	if !ok {
+		lock = &sync.Mutex{}
+		l.locks[modulePath] = lock
+	}
+	l.mu.Unlock()
+
+	lock.Lock()
+
+	return lock.Unlock
+}
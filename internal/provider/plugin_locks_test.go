@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPluginLocks(t *testing.T) {
+	ctx := context.Background()
+
+	fake := newFakeGotify()
+	fake.plugins[3] = &fakePlugin{ID: 3, Name: "Webhook", ModulePath: "github.com/gotify/plugin-webhook", Enabled: true, Config: "url: \"\"\n"}
+
+	// Calls take a while, so the ones of both resources can interleave.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Millisecond)
+		fake.ServeHTTP(w, r)
+	})
+
+	// Both resources share the client of the configured provider.
+	client := &GotifyClient{
+		Client:      &http.Client{Transport: &handlerTransport{handler: handler}},
+		Config:      GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+		pluginLocks: newPluginLocks(),
+	}
+	plugin := &PluginResource{client: client}
+	config := &PluginConfigResource{client: client}
+
+	pluginSchema := &fwresource.SchemaResponse{}
+	plugin.Schema(ctx, fwresource.SchemaRequest{}, pluginSchema)
+	configSchema := &fwresource.SchemaResponse{}
+	config.Schema(ctx, fwresource.SchemaRequest{}, configSchema)
+
+	pluginPlan := tfsdk.Plan{Schema: pluginSchema.Schema, Raw: tftypes.NewValue(pluginSchema.Schema.Type().TerraformType(ctx), nil)}
+	diags := pluginPlan.Set(ctx, &PluginResourceModel{
+		Id:         types.StringValue("3"),
+		ModulePath: types.StringValue("github.com/gotify/plugin-webhook"),
+		Name:       types.StringValue("Webhook"),
+		Enabled:    types.BoolValue(true),
+	})
+	if diags.HasError() {
+		t.Fatalf("can't build plan: %v", diags)
+	}
+
+	// The plugin is enabled and configured concurrently, as Terraform applies
+	// independent resources.
+	var wg sync.WaitGroup
+	errs := make(chan string, 100)
+	for i := 0; i < 50; i++ {
+		configPlan := tfsdk.Plan{Schema: configSchema.Schema, Raw: tftypes.NewValue(configSchema.Schema.Type().TerraformType(ctx), nil)}
+		diags := configPlan.Set(ctx, &PluginConfigResourceModel{
+			Id:            types.StringValue("3"),
+			ModulePath:    types.StringValue("github.com/gotify/plugin-webhook"),
+			Config:        types.StringValue(fmt.Sprintf("url: https://hooks.example.com/%d\n", i)),
+			AppliedConfig: types.StringUnknown(),
+			StrictKeys:    types.BoolValue(false),
+		})
+		if diags.HasError() {
+			t.Fatalf("can't build plan: %v", diags)
+		}
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			resp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: pluginPlan.Schema, Raw: pluginPlan.Raw}}
+			plugin.Update(ctx, fwresource.UpdateRequest{Plan: pluginPlan}, resp)
+			if resp.Diagnostics.HasError() {
+				errs <- fmt.Sprint(resp.Diagnostics)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			resp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: configPlan.Schema, Raw: configPlan.Raw}}
+			config.Update(ctx, fwresource.UpdateRequest{Plan: configPlan}, resp)
+			if resp.Diagnostics.HasError() {
+				errs <- fmt.Sprint(resp.Diagnostics)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("expected the changes of the plugin not to interleave, got %s", err)
+	}
+	if !fake.plugins[3].Enabled {
+		t.Fatal("expected the plugin to be left enabled")
+	}
+}
@@ -0,0 +1,394 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PluginResource{}
+var _ resource.ResourceWithImportState = &PluginResource{}
+var _ resource.ResourceWithValidateConfig = &PluginResource{}
+
+func NewPluginResource() resource.Resource {
+	return &PluginResource{}
+}
+
+// PluginResource defines the resource implementation.
+type PluginResource struct {
+	client *GotifyClient
+}
+
+// PluginResourceModel describes the resource data model.
+type PluginResourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	ModulePath types.String `tfsdk:"module_path"`
+	Name       types.String `tfsdk:"name"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+
+	Timeouts *RequestTimeoutsModel `tfsdk:"timeouts"`
+}
+
+// pluginResponse is a plugin as answered by Gotify.
+type pluginResponse struct {
+	ID           int64    `json:"id"`
+	Name         string   `json:"name"`
+	Token        string   `json:"token"`
+	ModulePath   string   `json:"modulePath"`
+	Enabled      bool     `json:"enabled"`
+	Capabilities []string `json:"capabilities"`
+	Author       string   `json:"author"`
+	Website      string   `json:"website"`
+	License      string   `json:"license"`
+}
+
+func (r *PluginResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plugin"
+}
+
+func (r *PluginResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Enables or disables a plugin installed on the Gotify server. Plugins can't be installed or uninstalled through the API, so the plugin must already be installed, and destroying the resource disables it. Import it with its module path.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Plugin identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"module_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Module path of the plugin, e.g. `github.com/gotify/plugin-webhook`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the plugin",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the plugin is enabled. Defaults to `true`",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": requestTimeoutsBlock("the plugin"),
+		},
+	}
+}
+
+func (r *PluginResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data PluginResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateTimeouts(data.Timeouts.timeouts(), path.Root("timeouts"))...)
+}
+
+func (r *PluginResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*GotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *GotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PluginResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PluginResourceModel
+
+	resp.Diagnostics.Append(r.client.checkReadOnly("create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.client.operationContext(ctx, "create", data.Timeouts.timeouts())
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	unlock := r.client.pluginLocks.lock(data.ModulePath.ValueString())
+	defer unlock()
+
+	plugin, diags := r.client.findPlugin(ctx, data.ModulePath.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plugin == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("module_path"),
+			"Plugin not installed",
+			fmt.Sprintf("No plugin with the module path %s is installed on the Gotify server. Plugins are installed by adding them to the plugin directory of the server.", data.ModulePath.ValueString()),
+		)
+		return
+	}
+
+	if plugin.Enabled != data.Enabled.ValueBool() {
+		resp.Diagnostics.Append(r.client.setPluginEnabled(ctx, plugin.ID, data.Enabled.ValueBool())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data.Id = types.StringValue(strconv.FormatInt(plugin.ID, 10))
+	data.Name = types.StringValue(plugin.Name)
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PluginResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PluginResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.client.operationContext(ctx, "read", data.Timeouts.timeouts())
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	plugin, diags := r.client.findPlugin(ctx, data.ModulePath.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plugin == nil {
+		tflog.Warn(ctx, fmt.Sprintf("Plugin %s not found, removing it from the state", data.ModulePath.ValueString()))
+		if !data.Id.IsNull() {
+			resp.Diagnostics.Append(r.client.notifyDrift(ctx, fmt.Sprintf("gotify_plugin %s was uninstalled outside of Terraform", data.ModulePath.ValueString()))...)
+		}
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Imported plugins have no prior values to drift from.
+	if !data.Enabled.IsNull() && data.Enabled.ValueBool() != plugin.Enabled {
+		resp.Diagnostics.Append(r.client.notifyDrift(ctx, fmt.Sprintf("gotify_plugin %s changed outside of Terraform: enabled", data.ModulePath.ValueString()))...)
+	}
+
+	data.Id = types.StringValue(strconv.FormatInt(plugin.ID, 10))
+	data.Name = types.StringValue(plugin.Name)
+	data.Enabled = types.BoolValue(plugin.Enabled)
+
+	tflog.Trace(ctx, "read a resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PluginResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PluginResourceModel
+
+	resp.Diagnostics.Append(r.client.checkReadOnly("update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.client.operationContext(ctx, "update", data.Timeouts.timeouts())
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	unlock := r.client.pluginLocks.lock(data.ModulePath.ValueString())
+	defer unlock()
+
+	id, err := strconv.ParseInt(data.Id.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid plugin id", fmt.Sprintf("The id %q of the plugin in the state isn't a number", data.Id.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(r.client.setPluginEnabled(ctx, id, data.Enabled.ValueBool())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete disables the plugin, which can't be uninstalled through the API.
+func (r *PluginResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PluginResourceModel
+
+	resp.Diagnostics.Append(r.client.checkReadOnly("delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.client.operationContext(ctx, "delete", data.Timeouts.timeouts())
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	unlock := r.client.pluginLocks.lock(data.ModulePath.ValueString())
+	defer unlock()
+
+	plugin, diags := r.client.findPlugin(ctx, data.ModulePath.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Plugins uninstalled or disabled outside of Terraform are left as they
+	// are.
+	if plugin == nil || !plugin.Enabled {
+		return
+	}
+
+	resp.Diagnostics.Append(r.client.setPluginEnabled(ctx, plugin.ID, false)...)
+}
+
+func (r *PluginResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("module_path"), req, resp)
+}
+
+// setPluginEnabled enables or disables the plugin identified by id.
+func (c *GotifyClient) setPluginEnabled(ctx context.Context, id int64, enabled bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	action := "disable"
+	if enabled {
+		action = "enable"
+	}
+
+	url := strings.Trim(c.Config.Url.String(), "\"")
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/plugin/%d/%s", url, id, action), nil)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		diags.AddError("Can't send request to Gotify", err.Error())
+		return diags
+	}
+
+	httpRes, err := c.Do(httpReq)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		c.addRequestError(&diags, err)
+		return diags
+	}
+	defer httpRes.Body.Close()
+
+	if err := checkResponse(httpRes); err != nil {
+		c.addResponseError(&diags, err)
+	}
+
+	return diags
+}
+
+// findPlugin returns the plugin installed with modulePath, or nil when there
+// is none.
+func (c *GotifyClient) findPlugin(ctx context.Context, modulePath string) (*pluginResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	plugins, err := c.listPlugins(ctx)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		var responseErr *responseError
+		if errors.As(err, &responseErr) {
+			c.addResponseError(&diags, err)
+		} else {
+			c.addRequestError(&diags, err)
+		}
+		return nil, diags
+	}
+
+	for _, plugin := range plugins {
+		if plugin.ModulePath == modulePath {
+			return &plugin, diags
+		}
+	}
+
+	return nil, diags
+}
+
+// listPlugins returns the plugins of the user ctx authenticates as.
+func (c *GotifyClient) listPlugins(ctx context.Context) ([]pluginResponse, error) {
+	url := strings.Trim(c.Config.Url.String(), "\"")
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url+"/plugin", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpRes, err := c.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpRes.Body.Close()
+
+	if err := checkResponse(httpRes); err != nil {
+		return nil, err
+	}
+
+	var plugins []pluginResponse
+	if err := c.decode(httpRes.Body, &plugins); err != nil {
+		return nil, err
+	}
+
+	return plugins, nil
+}
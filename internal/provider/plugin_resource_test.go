@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPluginResource(t *testing.T) {
+	ctx := context.Background()
+
+	fake := newFakeGotify()
+	fake.plugins[3] = &fakePlugin{ID: 3, Name: "Webhook", ModulePath: "github.com/gotify/plugin-webhook"}
+
+	r := &PluginResource{
+		client: &GotifyClient{
+			Client: &http.Client{Transport: newTransport(GotifyProviderModel{Mock: types.BoolValue(true)}, nil, "")},
+			Config: GotifyProviderModel{Url: NewURLValue(mockUrl), Mock: types.BoolValue(true)},
+		},
+	}
+	r.client.Transport.(*gotifyTransport).base = &handlerTransport{handler: fake}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	create := func(modulePath string) *fwresource.CreateResponse {
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+		diags := plan.Set(ctx, &PluginResourceModel{
+			Id:         types.StringUnknown(),
+			ModulePath: types.StringValue(modulePath),
+			Name:       types.StringUnknown(),
+			Enabled:    types.BoolValue(true),
+		})
+		if diags.HasError() {
+			t.Fatalf("can't build plan: %v", diags)
+		}
+
+		resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+		r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+
+		return resp
+	}
+
+	resp := create("github.com/gotify/plugin-missing")
+	if errs := resp.Diagnostics.Errors(); len(errs) != 1 || errs[0].Summary() != "Plugin not installed" {
+		t.Fatalf("expected a plugin not installed error, got %v", resp.Diagnostics)
+	}
+
+	resp = create("github.com/gotify/plugin-webhook")
+	var created PluginResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &created)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if created.Id.ValueString() != "3" || created.Name.ValueString() != "Webhook" || !fake.plugins[3].Enabled {
+		t.Fatalf("expected the plugin to be enabled, got %+v", created)
+	}
+
+	// Disabling the plugin outside of Terraform is refreshed.
+	fake.plugins[3].Enabled = false
+
+	readResp := &fwresource.ReadResponse{State: resp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: resp.State}, readResp)
+	var read PluginResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &read)...)
+	if readResp.Diagnostics.HasError() || read.Enabled.ValueBool() {
+		t.Fatalf("expected the plugin to be read as disabled, got %+v, %v", read, readResp.Diagnostics)
+	}
+
+	// The plugin is imported with its module path.
+	importResp := &fwresource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "github.com/gotify/plugin-webhook"}, importResp)
+
+	readResp = &fwresource.ReadResponse{State: importResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: importResp.State}, readResp)
+	var imported PluginResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &imported)...)
+	if importResp.Diagnostics.HasError() || readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v %v", importResp.Diagnostics, readResp.Diagnostics)
+	}
+	if imported != read {
+		t.Fatalf("expected the imported state to match the read one\nread:     %+v\nimported: %+v", read, imported)
+	}
+
+	// Destroying the resource disables the plugin, and leaves uninstalled
+	// plugins alone.
+	fake.plugins[3].Enabled = true
+
+	deleteResp := &fwresource.DeleteResponse{State: resp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: resp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() || fake.plugins[3].Enabled {
+		t.Fatalf("expected the plugin to be disabled, got %v", deleteResp.Diagnostics)
+	}
+
+	// Uninstalled plugins are removed from the state.
+	delete(fake.plugins, 3)
+
+	deleteResp = &fwresource.DeleteResponse{State: resp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: resp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors deleting an uninstalled plugin: %v", deleteResp.Diagnostics)
+	}
+
+	readResp = &fwresource.ReadResponse{State: resp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: resp.State}, readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Fatalf("expected the uninstalled plugin to be removed from the state, got %v", readResp.Diagnostics)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// webhookerCapability is the capability of plugins registering custom
+// routes.
+const webhookerCapability = "webhooker"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PluginsDataSource{}
+
+func NewPluginsDataSource() datasource.DataSource {
+	return &PluginsDataSource{}
+}
+
+// PluginsDataSource defines the data source implementation.
+type PluginsDataSource struct {
+	client *GotifyClient
+}
+
+// PluginsDataSourceModel describes the data source data model.
+type PluginsDataSourceModel struct {
+	Id         types.String  `tfsdk:"id"`
+	ModulePath types.String  `tfsdk:"module_path"`
+	Plugins    []PluginModel `tfsdk:"plugins"`
+}
+
+// PluginModel describes a plugin listed by the data source.
+type PluginModel struct {
+	Id             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	ModulePath     types.String   `tfsdk:"module_path"`
+	Enabled        types.Bool     `tfsdk:"enabled"`
+	Capabilities   []types.String `tfsdk:"capabilities"`
+	Token          types.String   `tfsdk:"token"`
+	CustomRouteUrl types.String   `tfsdk:"custom_route_url"`
+}
+
+func (d *PluginsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plugins"
+}
+
+func (d *PluginsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Plugins data source, exposing the URL of the custom routes registered by plugins such as the webhook plugin",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Placeholder identifier",
+			},
+			"module_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the plugin with this module path, e.g. `github.com/gotify/plugin-webhook`",
+			},
+			"plugins": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Plugins of the Gotify user",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Plugin identifier",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the plugin",
+						},
+						"module_path": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Module path of the plugin",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the plugin is enabled",
+						},
+						"capabilities": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Capabilities of the plugin, e.g. `webhooker` for plugins registering custom routes",
+						},
+						"token": schema.StringAttribute{
+							Computed:            true,
+							Sensitive:           true,
+							MarkdownDescription: "Token of the plugin",
+						},
+						"custom_route_url": schema.StringAttribute{
+							Computed:            true,
+							Sensitive:           true,
+							MarkdownDescription: "Base URL of the custom routes of the plugin, including its token. Empty unless the plugin has the `webhooker` capability",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PluginsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*GotifyClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *GotifyClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PluginsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PluginsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := d.client.operationContext(ctx, "read", nil)
+	defer cancel()
+
+	ctx = moduleContext(ctx, req.ProviderMeta)
+
+	url := strings.Trim(d.client.Config.Url.String(), "\"")
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url+"/plugin", nil)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		resp.Diagnostics.AddError("Can't send request to Gotify", err.Error())
+		return
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpRes, err := d.client.Do(httpReq)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		d.client.addRequestError(&resp.Diagnostics, err)
+		return
+	}
+	defer httpRes.Body.Close()
+
+	if err := checkResponse(httpRes); err != nil {
+		d.client.addResponseError(&resp.Diagnostics, err)
+		return
+	}
+
+	var respData []pluginResponse
+
+	err = d.client.decode(httpRes.Body, &respData)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error when contacting Gotify instance", err.Error())
+		return
+	}
+
+	data.Plugins = []PluginModel{}
+	for _, Plugin := range respData {
+		if !data.ModulePath.IsNull() && Plugin.ModulePath != data.ModulePath.ValueString() {
+			continue
+		}
+
+		plugin := PluginModel{
+			Id:             types.StringValue(strconv.FormatInt(Plugin.ID, 10)),
+			Name:           types.StringValue(Plugin.Name),
+			ModulePath:     types.StringValue(Plugin.ModulePath),
+			Enabled:        types.BoolValue(Plugin.Enabled),
+			Capabilities:   []types.String{},
+			Token:          types.StringValue(Plugin.Token),
+			CustomRouteUrl: types.StringValue(""),
+		}
+
+		for _, capability := range Plugin.Capabilities {
+			plugin.Capabilities = append(plugin.Capabilities, types.StringValue(capability))
+
+			if capability == webhookerCapability {
+				plugin.CustomRouteUrl = types.StringValue(customRouteUrl(url, Plugin.ID, Plugin.Token))
+			}
+		}
+
+		data.Plugins = append(data.Plugins, plugin)
+	}
+
+	data.Id = types.StringValue("plugins")
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// customRouteUrl returns the base URL of the custom routes registered by the
+// plugin id with the given token.
+func customRouteUrl(url string, id int64, token string) string {
+	return fmt.Sprintf("%s/plugin/%d/custom/%s/", strings.TrimSuffix(url, "/"), id, token)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPluginsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccProviderConfig() + testAccPluginsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gotify_plugins.test", "plugins.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestCustomRouteUrl(t *testing.T) {
+	if got := customRouteUrl("https://gotify.example.com/", 3, "PToken"); got != "https://gotify.example.com/plugin/3/custom/PToken/" {
+		t.Fatalf("unexpected custom route url %s", got)
+	}
+}
+
+const testAccPluginsDataSourceConfig = `
+data "gotify_plugins" "test" {
+  module_path = "example.com/tf-acc/no-such-plugin"
+}
+`
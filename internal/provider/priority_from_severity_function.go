@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// severityPriorities maps the severity levels of common vocabularies (syslog,
+// PagerDuty, log levels) to Gotify priorities.
+var severityPriorities = map[string]int64{
+	"emergency":     10,
+	"emerg":         10,
+	"panic":         10,
+	"alert":         9,
+	"fatal":         9,
+	"critical":      8,
+	"crit":          8,
+	"error":         7,
+	"err":           7,
+	"high":          7,
+	"warning":       5,
+	"warn":          5,
+	"normal":        4,
+	"notice":        4,
+	"info":          2,
+	"informational": 2,
+	"low":           2,
+	"debug":         0,
+	"trace":         0,
+}
+
+// priorityFromSeverity returns the Gotify priority matching level, ignoring
+// case and surrounding whitespace.
+func priorityFromSeverity(level string) (int64, error) {
+	priority, ok := severityPriorities[strings.ToLower(strings.TrimSpace(level))]
+	if !ok {
+		levels := make([]string, 0, len(severityPriorities))
+		for known := range severityPriorities {
+			levels = append(levels, known)
+		}
+		sort.Strings(levels)
+
+		return 0, fmt.Errorf("unknown severity %q, expected one of %s", level, strings.Join(levels, ", "))
+	}
+
+	return priority, nil
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &PriorityFromSeverityFunction{}
+
+func NewPriorityFromSeverityFunction() function.Function {
+	return &PriorityFromSeverityFunction{}
+}
+
+// PriorityFromSeverityFunction defines the function implementation.
+type PriorityFromSeverityFunction struct{}
+
+func (f *PriorityFromSeverityFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "priority_from_severity"
+}
+
+func (f *PriorityFromSeverityFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Gotify priority of a severity level",
+		MarkdownDescription: "Returns the Gotify priority matching a severity level from syslog (`emerg` to `debug`), PagerDuty (`critical`, `error`, `warning`, `info`) or common log levels. The level is case insensitive.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "level",
+				MarkdownDescription: "Severity level, e.g. `warning`",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *PriorityFromSeverityFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var level string
+
+	resp.Diagnostics.Append(req.Arguments.Get(ctx, &level)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	priority, err := priorityFromSeverity(level)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid severity level", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, priority)...)
+}
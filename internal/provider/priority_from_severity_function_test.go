@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPriorityFromSeverityFunction(t *testing.T) {
+	tests := map[string]struct {
+		level    string
+		expected int64
+		wantErr  bool
+	}{
+		"syslog emergency": {level: "emerg", expected: 10},
+		"pagerduty":        {level: "critical", expected: 8},
+		"mixed case":       {level: "Warning", expected: 5},
+		"whitespace":       {level: " info ", expected: 2},
+		"debug":            {level: "debug", expected: 0},
+		"unknown":          {level: "apocalyptic", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(test.level)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.Int64Unknown()),
+			}
+
+			NewPriorityFromSeverityFunction().Run(context.Background(), req, &resp)
+
+			if test.wantErr {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !resp.Result.Equal(function.NewResultData(types.Int64Value(test.expected))) {
+				t.Fatalf("expected %d, got %s", test.expected, resp.Result.Value())
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// priorityBands are the labels of the ranges of Gotify priorities, by their
+// lowest priority. The labels are severities priority_from_severity accepts.
+var priorityBands = []struct {
+	min  int64
+	name string
+}{
+	{min: 9, name: "emergency"},
+	{min: 7, name: "high"},
+	{min: 4, name: "normal"},
+	{min: 1, name: "low"},
+	{min: 0, name: "debug"},
+}
+
+// priorityName returns the label of the band priority belongs to. Priorities
+// above 10 are emergencies.
+func priorityName(priority int64) (string, error) {
+	if err := checkPriority(priority); err != nil {
+		return "", err
+	}
+
+	for _, band := range priorityBands {
+		if priority >= band.min {
+			return band.name, nil
+		}
+	}
+
+	return "", fmt.Errorf("priority %d has no band", priority)
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &PriorityNameFunction{}
+
+func NewPriorityNameFunction() function.Function {
+	return &PriorityNameFunction{}
+}
+
+// PriorityNameFunction defines the function implementation.
+type PriorityNameFunction struct{}
+
+func (f *PriorityNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "priority_name"
+}
+
+func (f *PriorityNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Label of a Gotify priority",
+		MarkdownDescription: "Returns the label of the band a Gotify priority belongs to: `debug` (0), `low` (1 to 3), `normal` (4 to 6), `high` (7 and 8) or `emergency` (9 and above). `priority_from_severity` maps the labels back to a priority.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:                "priority",
+				MarkdownDescription: "Gotify priority, e.g. `7`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *PriorityNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var priority int64
+
+	resp.Diagnostics.Append(req.Arguments.Get(ctx, &priority)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, err := priorityName(priority)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid priority", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, name)...)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPriorityNameFunction(t *testing.T) {
+	tests := map[string]struct {
+		priority int64
+		expected string
+		wantErr  bool
+	}{
+		"zero":            {priority: 0, expected: "debug"},
+		"low":             {priority: 2, expected: "low"},
+		"normal":          {priority: 5, expected: "normal"},
+		"high":            {priority: 7, expected: "high"},
+		"high upper":      {priority: 8, expected: "high"},
+		"emergency":       {priority: 10, expected: "emergency"},
+		"above emergency": {priority: 15, expected: "emergency"},
+		"negative":        {priority: -1, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.Int64Value(test.priority)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewPriorityNameFunction().Run(context.Background(), req, &resp)
+
+			if test.wantErr {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !resp.Result.Equal(function.NewResultData(types.StringValue(test.expected))) {
+				t.Fatalf("expected %q, got %s", test.expected, resp.Result.Value())
+			}
+		})
+	}
+}
+
+func TestPriorityNameRoundTrip(t *testing.T) {
+	for _, band := range priorityBands {
+		priority, err := priorityFromSeverity(band.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if name, _ := priorityName(priority); name != band.name {
+			t.Fatalf("expected %s to map back to itself, got %s", band.name, name)
+		}
+	}
+}
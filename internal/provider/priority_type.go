@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ basetypes.StringTypable = PriorityType{}
+var _ xattr.TypeWithValidate = PriorityType{}
+var _ basetypes.StringValuableWithSemanticEquals = PriorityValue{}
+
+// PriorityType is a string type for the priorities of Gotify messages and
+// applications, which are whole numbers from 0. Values are equal when they
+// are the same number, e.g. 05 and 5.
+type PriorityType struct {
+	basetypes.StringType
+}
+
+func (t PriorityType) Equal(o attr.Type) bool {
+	other, ok := o.(PriorityType)
+
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t PriorityType) String() string {
+	return "PriorityType"
+}
+
+func (t PriorityType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return PriorityValue{StringValue: in}, nil
+}
+
+func (t PriorityType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return PriorityValue{StringValue: stringValue}, nil
+}
+
+func (t PriorityType) ValueType(ctx context.Context) attr.Value {
+	return PriorityValue{}
+}
+
+func (t PriorityType) Validate(ctx context.Context, in tftypes.Value, valuePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !in.IsKnown() || in.IsNull() {
+		return diags
+	}
+
+	var value string
+	if err := in.As(&value); err != nil {
+		diags.AddAttributeError(valuePath, "Invalid priority", err.Error())
+		return diags
+	}
+
+	if _, err := parsePriority(value); err != nil {
+		diags.AddAttributeError(valuePath, "Invalid priority", err.Error())
+	}
+
+	return diags
+}
+
+// PriorityValue is a value of PriorityType.
+type PriorityValue struct {
+	basetypes.StringValue
+}
+
+// NewPriorityValue returns a known PriorityValue holding value.
+func NewPriorityValue(value string) PriorityValue {
+	return PriorityValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewPriorityValueInt64 returns a known PriorityValue holding priority, as
+// answered by Gotify.
+func NewPriorityValueInt64(priority int64) PriorityValue {
+	return NewPriorityValue(strconv.FormatInt(priority, 10))
+}
+
+func (v PriorityValue) Equal(o attr.Value) bool {
+	other, ok := o.(PriorityValue)
+
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v PriorityValue) Type(ctx context.Context) attr.Type {
+	return PriorityType{}
+}
+
+func (v PriorityValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(PriorityValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+
+		return false, diags
+	}
+
+	priority, err := parsePriority(v.ValueString())
+	newPriority, newErr := parsePriority(newValue.ValueString())
+	if err != nil || newErr != nil {
+		return v.ValueString() == newValue.ValueString(), diags
+	}
+
+	return priority == newPriority, diags
+}
+
+// Int64 returns the priority v holds, to send it to Gotify.
+func (v PriorityValue) Int64() (int64, error) {
+	return parsePriority(v.ValueString())
+}
+
+// parsePriority parses value as a priority.
+func parsePriority(value string) (int64, error) {
+	priority, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("priority %q isn't a whole number", value)
+	}
+
+	if err := checkPriority(priority); err != nil {
+		return 0, err
+	}
+
+	return priority, nil
+}
+
+// checkPriority returns an error unless priority is a valid priority.
+// Priorities above 10 are accepted by Gotify, and shown as emergencies.
+func checkPriority(priority int64) error {
+	if priority < 0 {
+		return fmt.Errorf("priority %d is negative", priority)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParsePriority(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected int64
+		wantErr  bool
+	}{
+		"lowest":          {value: "0", expected: 0},
+		"default":         {value: "1", expected: 1},
+		"highest band":    {value: "10", expected: 10},
+		"above 10":        {value: "11", expected: 11},
+		"leading zero":    {value: "05", expected: 5},
+		"plus sign":       {value: "+5", expected: 5},
+		"largest":         {value: "9223372036854775807", expected: 9223372036854775807},
+		"negative":        {value: "-1", wantErr: true},
+		"overflow":        {value: "9223372036854775808", wantErr: true},
+		"empty":           {value: "", wantErr: true},
+		"decimal":         {value: "1.5", wantErr: true},
+		"surrounded":      {value: " 5", wantErr: true},
+		"severity label":  {value: "high", wantErr: true},
+		"negative zero":   {value: "-0", expected: 0},
+		"hexadecimal":     {value: "0x5", wantErr: true},
+		"exponent":        {value: "1e1", wantErr: true},
+		"trailing letter": {value: "5a", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			priority, err := parsePriority(test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if priority != test.expected {
+				t.Fatalf("expected %d, got %d", test.expected, priority)
+			}
+		})
+	}
+}
+
+func TestPriorityTypeValidate(t *testing.T) {
+	tests := map[string]struct {
+		value   tftypes.Value
+		wantErr bool
+	}{
+		"lowest":   {value: tftypes.NewValue(tftypes.String, "0")},
+		"highest":  {value: tftypes.NewValue(tftypes.String, "10")},
+		"null":     {value: tftypes.NewValue(tftypes.String, nil)},
+		"unknown":  {value: tftypes.NewValue(tftypes.String, tftypes.UnknownValue)},
+		"negative": {value: tftypes.NewValue(tftypes.String, "-1"), wantErr: true},
+		"label":    {value: tftypes.NewValue(tftypes.String, "high"), wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := PriorityType{}.Validate(context.Background(), test.value, path.Root("default_priority"))
+			if diags.HasError() != test.wantErr {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+		})
+	}
+}
+
+func TestPriorityValueSemanticEquals(t *testing.T) {
+	tests := map[string]struct {
+		current  string
+		new      string
+		expected bool
+	}{
+		"identical":     {current: "5", new: "5", expected: true},
+		"leading zero":  {current: "05", new: "5", expected: true},
+		"plus sign":     {current: "+0", new: "0", expected: true},
+		"different":     {current: "5", new: "6", expected: false},
+		"invalid equal": {current: "high", new: "high", expected: true},
+		"invalid":       {current: "high", new: "7", expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := NewPriorityValue(test.current).StringSemanticEquals(context.Background(), NewPriorityValue(test.new))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if equal != test.expected {
+				t.Fatalf("expected %t, got %t", test.expected, equal)
+			}
+		})
+	}
+}
@@ -5,10 +5,16 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,6 +24,8 @@ import (
 
 // Ensure GotifyProvider satisfies various provider interfaces.
 var _ provider.Provider = &GotifyProvider{}
+var _ provider.ProviderWithFunctions = &GotifyProvider{}
+var _ provider.ProviderWithMetaSchema = &GotifyProvider{}
 
 // GotifyProvider defines the provider implementation.
 type GotifyProvider struct {
@@ -29,12 +37,58 @@ type GotifyProvider struct {
 
 // GotifyProviderModel describes the provider data model.
 type GotifyProviderModel struct {
-	Token types.String `tfsdk:"token"`
-	Url   types.String `tfsdk:"url"`
+	Token           types.String `tfsdk:"token"`
+	Url             URLValue     `tfsdk:"url"`
+	FollowRedirects types.Bool   `tfsdk:"follow_redirects"`
+	TlsServerName   types.String `tfsdk:"tls_server_name"`
+	HostHeader      types.String `tfsdk:"host_header"`
+	Username        types.String `tfsdk:"username"`
+	Password        types.String `tfsdk:"password"`
+	TokenInQuery    types.Bool   `tfsdk:"token_in_query"`
+	Urls            types.List   `tfsdk:"urls"`
+
+	AuthorizationBearer types.String `tfsdk:"authorization_bearer"`
+
+	DriftNotificationToken types.String `tfsdk:"drift_notification_token"`
+
+	DefaultApplicationImagePath types.String `tfsdk:"default_application_image_path"`
+
+	ProxyFromEnvironment types.Bool `tfsdk:"proxy_from_environment"`
+	ReadOnly             types.Bool `tfsdk:"read_only"`
+	LogOnly              types.Bool `tfsdk:"log_only"`
+	Mock                 types.Bool `tfsdk:"mock"`
+	StrictDecoding       types.Bool `tfsdk:"strict_decoding"`
+
+	MaxResponseSize    types.Int64  `tfsdk:"max_response_size"`
+	UserAgentExtra     types.String `tfsdk:"user_agent_extra"`
+	DataSourceCacheTtl types.String `tfsdk:"data_source_cache_ttl"`
+
+	DefaultTimeouts *TimeoutsModel `tfsdk:"default_timeouts"`
 }
 
-// variable contains provider configuration
-var Config GotifyProviderModel
+// mockUrl is the URL requests are built with when mock is set and no url
+// is configured.
+const mockUrl = "http://gotify.mock"
+
+// GotifyClient is the client shared by the resources and data sources of a
+// configured provider. Resources use it concurrently, so its only mutable
+// state is synchronized.
+type GotifyClient struct {
+	*http.Client
+
+	// Config is the provider configuration, with Url set to the URL requests
+	// are built with.
+	Config GotifyProviderModel
+
+	// plannedNames are the names of the applications planned in this run.
+	plannedNames *nameRegistry
+
+	// failures counts the failed calls to Gotify of this run.
+	failures *callFailureStats
+
+	// pluginLocks serialize the changes made to each plugin in this run.
+	pluginLocks *pluginLocks
+}
 
 func (p *GotifyProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "gotify"
@@ -43,15 +97,124 @@ func (p *GotifyProvider) Metadata(ctx context.Context, req provider.MetadataRequ
 
 func (p *GotifyProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "Every attribute which isn't set falls back to the environment variable named after it, e.g. `GOTIFY_TOKEN` for `token`. `token`, `username` and `password` are only read from the environment when none of them is set. `urls` are read as a comma separated list from `GOTIFY_URLS`, and `default_timeouts` from `GOTIFY_DEFAULT_TIMEOUTS_CREATE`, `GOTIFY_DEFAULT_TIMEOUTS_READ`, `GOTIFY_DEFAULT_TIMEOUTS_UPDATE`, `GOTIFY_DEFAULT_TIMEOUTS_DELETE`, `GOTIFY_DEFAULT_TIMEOUTS_REQUEST` and `GOTIFY_DEFAULT_TIMEOUTS_UPLOAD`.",
 		Attributes: map[string]schema.Attribute{
 			"token": schema.StringAttribute{
-				MarkdownDescription: "Token of Gotify Client",
-				Required:            true,
-				Optional:            false,
+				MarkdownDescription: "Token of Gotify Client. Without `token` nor `username` and `password`, only the `gotify_health` and `gotify_version` data sources can be used",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"token_in_query": schema.BoolAttribute{
+				MarkdownDescription: "Send `token` as the `token` query parameter instead of the `X-Gotify-Key` header, for proxies stripping custom headers. Defaults to `false`",
+				Optional:            true,
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Name of a Gotify user, sent with `password` as basic auth on every call instead of `token`",
+				Optional:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password of the Gotify user set in `username`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"authorization_bearer": schema.StringAttribute{
+				MarkdownDescription: "Token sent as `Authorization: Bearer` with every call, for Gotify instances behind an authenticating proxy such as OAuth2 Proxy or Authelia. Gotify still authenticates calls with `token`, which can't be combined with `username` and `password`",
+				Optional:            true,
+				Sensitive:           true,
 			},
 			"url": schema.StringAttribute{
-				MarkdownDescription: "URL for Gotify Instance",
-				Required:            true,
+				MarkdownDescription: "URL for Gotify Instance. Required unless `urls` is set",
+				CustomType:          URLType{},
+				Optional:            true,
+			},
+			"urls": schema.ListAttribute{
+				MarkdownDescription: "URLs of the same Gotify Instance, tried in order. When an URL is unreachable, calls fail over to the next one",
+				ElementType:         URLType{},
+				Optional:            true,
+			},
+			"drift_notification_token": schema.StringAttribute{
+				MarkdownDescription: "Token of a Gotify application a message is sent with whenever a refresh finds a resource changed or deleted outside of Terraform. No message is sent when `read_only` is set",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"default_application_image_path": schema.StringAttribute{
+				MarkdownDescription: "Path to a png, jpeg or gif file uploaded as the icon of every `gotify_application` without `image`, e.g. the standard icon of an organization",
+				Optional:            true,
+			},
+			"follow_redirects": schema.BoolAttribute{
+				MarkdownDescription: "Follow redirects answered to GET requests. Requests modifying Gotify never follow redirects. Defaults to `false`",
+				Optional:            true,
+			},
+			"strict_decoding": schema.BoolAttribute{
+				MarkdownDescription: "Fail when Gotify answers with fields the provider doesn't know, or when its major version isn't the one the provider supports, to catch skew between the provider and the server, e.g. in staging. Defaults to `false`",
+				Optional:            true,
+			},
+			"tls_server_name": schema.StringAttribute{
+				MarkdownDescription: "Server name used to verify the TLS certificate of the Gotify instance, when it differs from the host of `url`",
+				Optional:            true,
+			},
+			"proxy_from_environment": schema.BoolAttribute{
+				MarkdownDescription: "Send requests through the proxies set in the `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY` environment variables. Set to `false` to always connect directly. Defaults to `true`",
+				Optional:            true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Only allow reading from Gotify. Creating, updating or deleting resources fails. Defaults to `false`",
+				Optional:            true,
+			},
+			"log_only": schema.BoolAttribute{
+				MarkdownDescription: "Log the calls creating, updating or deleting objects, with their method, path and payload, instead of sending them to Gotify, and answer them with synthetic ids and tokens. Reads still reach Gotify. Meant to demo changes against a production configuration: the objects created don't exist, so the next refresh plans them again. Defaults to `false`",
+				Optional:            true,
+			},
+			"user_agent_extra": schema.StringAttribute{
+				MarkdownDescription: "Suffix appended to the User-Agent header of every request, e.g. the name of a team or the id of a pipeline, to attribute changes in the logs of reverse proxies",
+				Optional:            true,
+			},
+			"data_source_cache_ttl": schema.StringAttribute{
+				MarkdownDescription: "Duration, such as `30s` or `5m`, the answers read by data sources are reused for within one Terraform operation, so data sources listing the same objects send a single request. Any change made to Gotify clears them. Not cached by default",
+				Optional:            true,
+			},
+			"max_response_size": schema.Int64Attribute{
+				MarkdownDescription: "Maximum size in bytes of a response from Gotify. Reading a larger response fails instead of exhausting the memory of the provider. Defaults to `67108864` (64 MiB)",
+				Optional:            true,
+			},
+			"mock": schema.BoolAttribute{
+				MarkdownDescription: "Run every call against a fake Gotify instead of a live server, for `terraform test` suites. `url` and credentials aren't required. The content of the fake is persisted to a file of the temporary directory keyed by the working directory, so the commands of a run, e.g. `plan` then `apply`, share it. Delete the `terraform-provider-gotify-mock-*.json` files to start from an empty fake. Defaults to `false`",
+				Optional:            true,
+			},
+			"host_header": schema.StringAttribute{
+				MarkdownDescription: "Value of the Host header sent to the Gotify instance, when it differs from the host of `url`",
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"default_timeouts": schema.SingleNestedBlock{
+				MarkdownDescription: "Timeouts of the operations on resources, unless set in their `timeouts` block. The `read` and `request` timeouts also apply to data sources. Without timeout, operations wait until Terraform is interrupted",
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{
+						MarkdownDescription: "Timeout of resource creations, as a duration such as `30s` or `5m`",
+						Optional:            true,
+					},
+					"read": schema.StringAttribute{
+						MarkdownDescription: "Timeout of resource and data source reads, as a duration such as `30s` or `5m`",
+						Optional:            true,
+					},
+					"update": schema.StringAttribute{
+						MarkdownDescription: "Timeout of resource updates, as a duration such as `30s` or `5m`",
+						Optional:            true,
+					},
+					"delete": schema.StringAttribute{
+						MarkdownDescription: "Timeout of resource deletions, as a duration such as `30s` or `5m`",
+						Optional:            true,
+					},
+					"request": schema.StringAttribute{
+						MarkdownDescription: "Timeout of each call to Gotify made by resources and data sources, including reading its answer, as a duration such as `30s` or `5m`. Without it, calls are only bound by the timeout of their operation",
+						Optional:            true,
+					},
+					"upload": schema.StringAttribute{
+						MarkdownDescription: "Timeout of each upload of an application image, as a duration such as `30s` or `5m`. Defaults to the `request` timeout",
+						Optional:            true,
+					},
+				},
 			},
 		},
 	}
@@ -66,12 +229,113 @@ func (p *GotifyProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	resp.Diagnostics.Append(applyEnvironment(&data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.MaxResponseSize.IsNull() && data.MaxResponseSize.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_response_size"), "Invalid max_response_size", "max_response_size must be positive")
+		return
+	}
+
+	resp.Diagnostics.Append(validateTimeouts(data.DefaultTimeouts, path.Root("default_timeouts"))...)
+
+	if !data.DataSourceCacheTtl.IsNull() {
+		if ttl, err := time.ParseDuration(data.DataSourceCacheTtl.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("data_source_cache_ttl"), "Invalid data_source_cache_ttl", err.Error())
+		} else if ttl < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("data_source_cache_ttl"), "Invalid data_source_cache_ttl", "data_source_cache_ttl can't be negative")
+		}
+	}
+
+	if !data.DefaultApplicationImagePath.IsNull() {
+		if err := validateImageFile(data.DefaultApplicationImagePath.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("default_application_image_path"), "Invalid default_application_image_path", err.Error())
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var urls []string
+	resp.Diagnostics.Append(data.Urls.ElementsAs(ctx, &urls, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(urls) > 0 && !data.Url.IsNull() {
+		resp.Diagnostics.AddError("Conflicting Gotify URLs", "Only one of url and urls can be set")
+		return
+	}
+
+	// Requests are built against the first URL, the transport sends them to
+	// whichever URL is currently reachable.
+	if len(urls) > 0 {
+		data.Url = NewURLValue(urls[0])
+	}
+
+	// The fake backend answers any URL and accepts any credentials.
+	if data.Mock.ValueBool() && data.Url.IsNull() {
+		data.Url = NewURLValue(mockUrl)
+	}
+
+	if data.Url.IsNull() {
+		resp.Diagnostics.AddError("Missing Gotify URL", "Either url or urls must be set")
+		return
+	}
+
+	// URLs are normalized so paths can be appended to them, even when they
+	// were set with a trailing slash.
+	for i := range urls {
+		urls[i] = normalizeURL(urls[i])
+	}
+	data.Url = NewURLValue(normalizeURL(data.Url.ValueString()))
+
 	url := strings.Trim(data.Url.String(), "\"")
-	token := strings.Trim(data.Token.String(), "\"")
-	// priority := data.Priority
-	client := http.DefaultClient
+	if len(urls) == 0 {
+		urls = []string{url}
+	}
+
+	if data.Username.IsNull() != data.Password.IsNull() {
+		resp.Diagnostics.AddError("Incomplete basic auth credentials", "Both username and password must be set to use basic auth")
+		return
+	}
+
+	// Basic auth and the bearer token are both sent in the Authorization
+	// header.
+	if !data.Username.IsNull() && !data.AuthorizationBearer.IsNull() {
+		resp.Diagnostics.AddError("Conflicting credentials", "authorization_bearer can't be combined with username and password, use token to authenticate with Gotify")
+		return
+	}
+
+	client := &http.Client{
+		Transport:     newTransport(data, urls, userAgent(req.TerraformVersion, p.version, data.UserAgentExtra.ValueString())),
+		CheckRedirect: redirectPolicy(data.FollowRedirects.ValueBool()),
+	}
+
+	gotifyClient := &GotifyClient{
+		Client:       client,
+		Config:       data,
+		plannedNames: newNameRegistry(),
+		failures:     &callFailureStats{},
+		pluginLocks:  newPluginLocks(),
+	}
+
+	probeCtx, cancel := gotifyClient.operationContext(ctx, "read", nil)
+	defer cancel()
 
-	httpReq, err := http.NewRequest("GET", url+"/application", nil)
+	// Without credentials, only the reachability of Gotify can be checked:
+	// they are enforced when an authenticated call is attempted.
+	probePath := "/application"
+	if !hasCredentials(data) {
+		probePath = "/version"
+	}
+
+	httpReq, err := http.NewRequestWithContext(probeCtx, "GET", url+probePath, nil)
 	if err != nil {
 		tflog.Error(ctx, err.Error())
 		resp.Diagnostics.AddError("API Error when contacting Gotify instance", err.Error())
@@ -79,41 +343,146 @@ func (p *GotifyProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("X-Gotify-Key", token)
 
-	httpRes, err := client.Do(httpReq)
+	httpRes, err := gotifyClient.Do(httpReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Can't contact Gotify Instance", err.Error())
+		gotifyClient.addRequestError(&resp.Diagnostics, err)
 		return
 	}
 
 	defer httpRes.Body.Close()
 
-	statusCode := httpRes.StatusCode
-
-	if statusCode == 401 {
-		resp.Diagnostics.AddError("Not Allowed", "Bad token (?)")
-		return
-	} else if statusCode != 200 {
-		resp.Diagnostics.AddError("API Error when contacting Gotify instance", "Received a non-200 response code")
+	if err := checkResponse(httpRes); err != nil {
+		gotifyClient.addResponseError(&resp.Diagnostics, err)
 		return
 	}
 
-	Config = data
+	if data.LogOnly.ValueBool() {
+		resp.Diagnostics.AddWarning("Log-only mode", "The provider is configured with log_only = true: the changes to Gotify are only logged, with TF_LOG=INFO, and nothing is created, updated or deleted.")
+	}
+
+	version, versionErr := gotifyClient.serverVersion(probeCtx)
+
+	if data.StrictDecoding.ValueBool() {
+		err := versionErr
+		if err == nil {
+			err = checkStrictVersion(version)
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddError("Unsupported Gotify server", err.Error())
+			return
+		}
+	}
+
+	// Old servers are only warned about, as most features still work with
+	// them.
+	if versionErr != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Can't read the version of Gotify, features needing a recent server aren't checked: %s", versionErr))
+	} else {
+		resp.Diagnostics.Append(checkFeatureVersions(version)...)
+	}
+
+	resp.DataSourceData = gotifyClient
+	resp.ResourceData = gotifyClient
+}
+
+// unauthenticatedPaths are the paths of the Gotify API which can be called
+// without credentials.
+var unauthenticatedPaths = []string{"/health", "/version"}
+
+// errMissingCredentials is returned by GotifyClient.Do when an authenticated
+// call is attempted without any credentials configured.
+var errMissingCredentials = errors.New("neither token nor username and password are set")
+
+// hasCredentials reports whether data holds credentials to authenticate
+// calls with. The fake backend of mock mode accepts any call.
+func hasCredentials(data GotifyProviderModel) bool {
+	return data.Token.ValueString() != "" || data.Username.ValueString() != "" || data.Mock.ValueBool()
+}
+
+// Do sends req with the provider client, failing without sending it when it
+// requires credentials and none are configured.
+func (c *GotifyClient) Do(req *http.Request) (*http.Response, error) {
+	if _, ok := credentialsFromContext(req.Context()); !ok && !hasCredentials(c.Config) {
+		if !contains(unauthenticatedPaths, strings.TrimPrefix(req.URL.Path, urlPath(c.Config.Url.ValueString()))) {
+			return nil, fmt.Errorf("%s %s requires authentication: %w", req.Method, req.URL.Path, errMissingCredentials)
+		}
+	}
+
+	// Errors of the client include the URL, which may contain a token.
+	httpRes, err := c.Client.Do(req)
+	if err != nil {
+		return nil, &redactedError{err: err}
+	}
+
+	return httpRes, nil
+}
+
+// checkReadOnly returns an error diagnostic when the provider is configured
+// as read-only, as operation would modify Gotify.
+func (c *GotifyClient) checkReadOnly(operation string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if c.Config.ReadOnly.ValueBool() {
+		diags.AddError("Provider is read-only", fmt.Sprintf("Can't %s the resource: the provider is configured with read_only = true", operation))
+	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	return diags
+}
+
+// redirectPolicy refuses redirects on requests modifying Gotify, as the
+// request body would be dropped or the request silently turned into a GET.
+// Redirects on GET requests are only followed when follow is set.
+func redirectPolicy(follow bool) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		original := via[0]
+
+		if original.Method != http.MethodGet || !follow {
+			return fmt.Errorf("gotify redirected %s %s to %s, set the provider url to the canonical address (e.g. %s://%s)", original.Method, redactURL(original.URL), redactURL(req.URL), req.URL.Scheme, req.URL.Host)
+		}
+
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+
+		return nil
+	}
 }
 
 func (p *GotifyProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewApplicationResource,
+		NewPluginConfigResource,
+		NewPluginResource,
 	}
 }
 
 func (p *GotifyProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewApplicationDataSource,
+		NewApplicationsDataSource,
+		NewClientsDataSource,
+		NewHealthDataSource,
+		NewMessageDataSource,
+		NewMessageStatsDataSource,
+		NewMessagesDataSource,
+		NewPluginConfigDataSource,
+		NewPluginsDataSource,
+		NewVersionDataSource,
+	}
+}
+
+func (p *GotifyProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewApplicationDescriptionFunction,
+		NewClientNameFunction,
+		NewEnvironmentTokenFunction,
+		NewParsePushUrlFunction,
+		NewPriorityFromSeverityFunction,
+		NewPriorityNameFunction,
+		NewSplitMessageFunction,
+		NewTruncateMessageFunction,
 	}
 }
 
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/metaschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// managedByHeader is the header the calls to Gotify are sent with when the
+// module of the resource or data source sets module_name.
+const managedByHeader = "X-Managed-By"
+
+func (p *GotifyProvider) MetaSchema(ctx context.Context, req provider.MetaSchemaRequest, resp *provider.MetaSchemaResponse) {
+	resp.Schema = metaschema.Schema{
+		Attributes: map[string]metaschema.Attribute{
+			"module_name": metaschema.StringAttribute{
+				MarkdownDescription: "Name of the module, sent as the `X-Managed-By` header with the calls to Gotify of its resources and data sources, to trace which module owns which API traffic",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// moduleNameKey is the context key of the module_name sent with calls.
+type moduleNameKey struct{}
+
+// moduleContext returns ctx sending the module_name set in the
+// provider_meta block of the module, if any, with its calls to Gotify.
+func moduleContext(ctx context.Context, meta tfsdk.Config) context.Context {
+	if meta.Raw.IsNull() {
+		return ctx
+	}
+
+	var name types.String
+	if diags := meta.GetAttribute(ctx, path.Root("module_name"), &name); diags.HasError() {
+		tflog.Warn(ctx, fmt.Sprintf("Can't read module_name from provider_meta: %v", diags))
+		return ctx
+	}
+
+	if name.ValueString() == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, moduleNameKey{}, name.ValueString())
+}
+
+// moduleNameFromContext returns the module_name set on ctx by
+// moduleContext, if any.
+func moduleNameFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(moduleNameKey{}).(string)
+	return name, ok
+}
@@ -4,10 +4,18 @@
 package provider
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -15,11 +23,222 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
+	"gotify": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// TestMain gives the tests a temporary directory of their own, as mock mode
+// persists its fake there, keyed by the working directory only.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "terraform-provider-gotify-test")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	os.Setenv("TMPDIR", dir)
+	code := m.Run()
+	os.RemoveAll(dir)
+
+	os.Exit(code)
 }
 
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	for _, env := range []string{"GOTIFY_URL", "GOTIFY_TOKEN"} {
+		if os.Getenv(env) == "" {
+			t.Fatalf("%s must be set for acceptance tests", env)
+		}
+	}
+}
+
+// testAccProviderConfig configures the provider from the environment
+// variables checked by testAccPreCheck.
+func testAccProviderConfig() string {
+	return fmt.Sprintf(`
+provider "gotify" {
+  url   = %q
+  token = %q
+}
+`, os.Getenv("GOTIFY_URL"), os.Getenv("GOTIFY_TOKEN"))
+}
+
+// TestAccProviderAuthentication reads the applications with each way of
+// authenticating with Gotify, so a broken one fails on its own rather than
+// in every resource test. Basic auth is tested when GOTIFY_USERNAME and
+// GOTIFY_PASSWORD are set.
+func TestAccProviderAuthentication(t *testing.T) {
+	url, token := os.Getenv("GOTIFY_URL"), os.Getenv("GOTIFY_TOKEN")
+	username, password := os.Getenv("GOTIFY_USERNAME"), os.Getenv("GOTIFY_PASSWORD")
+
+	tests := map[string]struct {
+		config      string
+		env         map[string]string
+		basicAuth   bool
+		expectError *regexp.Regexp
+	}{
+		"client token": {
+			config: fmt.Sprintf(`
+provider "gotify" {
+  url   = %q
+  token = %q
+}
+`, url, token),
+		},
+		"token in query": {
+			config: fmt.Sprintf(`
+provider "gotify" {
+  url            = %q
+  token          = %q
+  token_in_query = true
+}
+`, url, token),
+		},
+		"basic auth": {
+			config: fmt.Sprintf(`
+provider "gotify" {
+  url      = %q
+  username = %q
+  password = %q
+}
+`, url, username, password),
+			basicAuth: true,
+		},
+		"environment variables": {
+			config: `
+provider "gotify" {}
+`,
+			env: map[string]string{"GOTIFY_USERNAME": "", "GOTIFY_PASSWORD": ""},
+		},
+		"environment variables with basic auth": {
+			config: `
+provider "gotify" {}
+`,
+			env:       map[string]string{"GOTIFY_TOKEN": ""},
+			basicAuth: true,
+		},
+		"invalid token": {
+			config: fmt.Sprintf(`
+provider "gotify" {
+  url   = %q
+  token = "Ainvalid-token"
+}
+`, url),
+			expectError: regexp.MustCompile(`(?i)unauthorized|not allowed`),
+		},
+		"missing credentials": {
+			config: fmt.Sprintf(`
+provider "gotify" {
+  url = %q
+}
+`, url),
+			env:         map[string]string{"GOTIFY_TOKEN": "", "GOTIFY_USERNAME": "", "GOTIFY_PASSWORD": ""},
+			expectError: regexp.MustCompile(`neither token nor username and password are set`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if test.basicAuth && (username == "" || password == "") {
+				t.Skip("GOTIFY_USERNAME and GOTIFY_PASSWORD must be set to test basic auth")
+			}
+
+			step := resource.TestStep{
+				Config: test.config + `
+data "gotify_applications" "test" {}
+`,
+				ExpectError: test.expectError,
+			}
+			if test.expectError == nil {
+				step.Check = resource.TestCheckResourceAttrSet("data.gotify_applications.test", "total_count")
+			}
+
+			resource.Test(t, resource.TestCase{
+				PreCheck: func() {
+					testAccPreCheck(t)
+
+					for env, value := range test.env {
+						t.Setenv(env, value)
+					}
+				},
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
+
+func TestRedirectPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/application" {
+			http.Redirect(w, r, "/canonical/application", http.StatusMovedPermanently)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		method  string
+		follow  bool
+		wantErr bool
+	}{
+		"get not followed": {method: http.MethodGet, follow: false, wantErr: true},
+		"get followed":     {method: http.MethodGet, follow: true, wantErr: false},
+		"post":             {method: http.MethodPost, follow: true, wantErr: true},
+		"delete":           {method: http.MethodDelete, follow: true, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &http.Client{CheckRedirect: redirectPolicy(test.follow)}
+
+			req, err := http.NewRequest(test.method, server.URL+"/application", strings.NewReader("{}"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := client.Do(req)
+			if res != nil {
+				res.Body.Close()
+			}
+
+			if test.wantErr && (err == nil || !strings.Contains(err.Error(), "canonical address")) {
+				t.Fatalf("expected a redirect error, got %v", err)
+			}
+
+			if !test.wantErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestGotifyClientMissingCredentials(t *testing.T) {
+	client := &GotifyClient{
+		Client: &http.Client{Transport: &handlerTransport{handler: newFakeGotify()}},
+		Config: GotifyProviderModel{Url: NewURLValue("https://gotify.example.com/gotify")},
+	}
+
+	for path, wantErr := range map[string]bool{
+		"/gotify/version":     false,
+		"/gotify/health":      false,
+		"/gotify/application": true,
+	} {
+		req, err := http.NewRequest(http.MethodGet, "https://gotify.example.com"+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := client.Do(req)
+		if res != nil {
+			res.Body.Close()
+		}
+
+		if wantErr && !errors.Is(err, errMissingCredentials) {
+			t.Fatalf("expected %s to fail with missing credentials, got %v", path, err)
+		}
+
+		if !wantErr && err != nil {
+			t.Fatalf("unexpected error calling %s: %s", path, err)
+		}
+	}
 }
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// listOperations are the calls listing objects, which data sources read in
+// parallel by Terraform send concurrently, as grouped by callOperation.
+var listOperations = map[string]bool{
+	"GET /application":              true,
+	"GET /application/{id}/message": true,
+	"GET /client":                   true,
+	"GET /message":                  true,
+	"GET /plugin":                   true,
+}
+
+// requestGroup collapses identical requests sent concurrently into one: the
+// first one is sent, and the others wait for its answer, so a plan reading
+// many data sources doesn't send the same list requests to small instances
+// all at once.
+type requestGroup struct {
+	mu    sync.Mutex
+	calls map[string]*groupedCall
+}
+
+// groupedCall is a request in flight, and its answer once done is closed.
+type groupedCall struct {
+	done     chan struct{}
+	response cachedResponse
+	err      error
+}
+
+func newRequestGroup() *requestGroup {
+	return &requestGroup{
+		calls: map[string]*groupedCall{},
+	}
+}
+
+// do returns the answer of send, which sends req, unless a request with the
+// same key is in flight, whose answer is returned instead. Each caller gets
+// its own copy of the answer.
+func (g *requestGroup) do(req *http.Request, key string, send func() (*http.Response, error)) (*http.Response, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+		if call.err != nil {
+			return nil, call.err
+		}
+		return call.response.toResponse(req), nil
+	}
+
+	call := &groupedCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	// The answer is read whole, so it can be handed to the waiting callers.
+	res, err := send()
+	if err == nil {
+		var body []byte
+		body, err = io.ReadAll(res.Body)
+		res.Body.Close()
+		call.response = cachedResponse{status: res.StatusCode, header: res.Header.Clone(), body: body}
+	}
+	call.err = err
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+
+	if err != nil {
+		return nil, err
+	}
+	return call.response.toResponse(req), nil
+}
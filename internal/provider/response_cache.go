@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// responseCache keeps the successful answers to GET requests for ttl, so
+// data sources reading the same list within one Terraform operation send a
+// single request.
+type responseCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+	// generation is incremented by clear, so the answers to requests sent
+	// before a change aren't kept once it was made.
+	generation uint64
+}
+
+// cachedResponse is a response kept by responseCache.
+type cachedResponse struct {
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// toResponse returns a copy of r answering req.
+func (r cachedResponse) toResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.status, http.StatusText(r.status)),
+		StatusCode:    r.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.body)),
+		ContentLength: int64(len(r.body)),
+		Request:       req,
+	}
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: map[string]cachedResponse{},
+	}
+}
+
+// cacheKey identifies the answer to req, which depends on the credentials
+// it is sent with as Gotify scopes applications and clients to users.
+func cacheKey(req *http.Request, creds credentials) string {
+	return fmt.Sprintf("%s %s %s:%s:%s", req.Method, req.URL.String(), creds.token, creds.username, creds.password)
+}
+
+// get returns a copy of the response kept for key, if it hasn't expired.
+func (c *responseCache) get(req *http.Request, key string) (*http.Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return entry.toResponse(req), true
+}
+
+// currentGeneration returns the generation requests sent now are answered
+// in, to pass to put.
+func (c *responseCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generation
+}
+
+// put keeps res for key when it succeeded and the cache wasn't cleared
+// since its request was sent in generation, returning a response to use
+// instead of res as its body was consumed.
+func (c *responseCache) put(key string, generation uint64, res *http.Response) (*http.Response, error) {
+	if res.StatusCode != http.StatusOK {
+		return res, nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.generation == generation {
+		c.entries[key] = cachedResponse{
+			status:  res.StatusCode,
+			header:  res.Header.Clone(),
+			body:    body,
+			expires: time.Now().Add(c.ttl),
+		}
+	}
+	c.mu.Unlock()
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	return res, nil
+}
+
+// clear forgets every response, as any change to Gotify can alter them.
+func (c *responseCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = map[string]cachedResponse{}
+	c.generation++
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// sortFields are the values sort_by accepts.
+var sortFields = []string{"id", "name", "last_used"}
+
+// sortKey holds the fields a listed entry can be sorted by.
+type sortKey struct {
+	id       int64
+	name     string
+	lastUsed string
+}
+
+// validateSort returns an error when sortBy or sortOrder hold an unsupported
+// value.
+func validateSort(sortBy types.String, sortOrder types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !sortBy.IsNull() && !contains(sortFields, sortBy.ValueString()) {
+		diags.AddAttributeError(path.Root("sort_by"), "Invalid sort_by", fmt.Sprintf("sort_by must be one of %s", strings.Join(sortFields, ", ")))
+	}
+
+	if !sortOrder.IsNull() && sortOrder.ValueString() != "asc" && sortOrder.ValueString() != "desc" {
+		diags.AddAttributeError(path.Root("sort_order"), "Invalid sort_order", "sort_order must be asc or desc")
+	}
+
+	return diags
+}
+
+// sortEntries sorts entries by the field sortBy, id when null, in the order
+// sortOrder, ascending when null. Entries with equal fields are ordered by
+// id so the result doesn't depend on the order Gotify listed them in.
+func sortEntries[T any](entries []T, keyOf func(T) sortKey, sortBy types.String, sortOrder types.String) {
+	field := sortBy.ValueString()
+	descending := sortOrder.ValueString() == "desc"
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := keyOf(entries[i]), keyOf(entries[j])
+		if descending {
+			a, b = b, a
+		}
+
+		switch {
+		case field == "name" && a.name != b.name:
+			return a.name < b.name
+		case field == "last_used" && a.lastUsed != b.lastUsed:
+			return a.lastUsed < b.lastUsed
+		}
+
+		return a.id < b.id
+	})
+}
+
+// validatePage returns an error when offset is negative or limit isn't
+// positive.
+func validatePage(offset types.Int64, limit types.Int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if offset.ValueInt64() < 0 {
+		diags.AddAttributeError(path.Root("offset"), "Invalid offset", "offset can't be negative")
+	}
+
+	if !limit.IsNull() && limit.ValueInt64() <= 0 {
+		diags.AddAttributeError(path.Root("limit"), "Invalid limit", "limit must be positive")
+	}
+
+	return diags
+}
+
+// pageEntries returns the entries after the first offset ones, at most
+// limit of them when set.
+func pageEntries[T any](entries []T, offset types.Int64, limit types.Int64) []T {
+	start := len(entries)
+	if offset.ValueInt64() < int64(start) {
+		start = int(offset.ValueInt64())
+	}
+
+	// The limit is compared to the remaining entries, as adding it to start
+	// overflows for limits close to the largest number.
+	end := len(entries)
+	if !limit.IsNull() && limit.ValueInt64() < int64(end-start) {
+		end = start + int(limit.ValueInt64())
+	}
+
+	return entries[start:end]
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSortEntries(t *testing.T) {
+	entries := []sortKey{
+		{id: 3, name: "beta", lastUsed: "2024-01-02T00:00:00Z"},
+		{id: 1, name: "beta", lastUsed: ""},
+		{id: 2, name: "alpha", lastUsed: "2024-01-01T00:00:00Z"},
+	}
+
+	tests := map[string]struct {
+		sortBy    types.String
+		sortOrder types.String
+		expected  []int64
+	}{
+		"default":        {sortBy: types.StringNull(), sortOrder: types.StringNull(), expected: []int64{1, 2, 3}},
+		"id descending":  {sortBy: types.StringValue("id"), sortOrder: types.StringValue("desc"), expected: []int64{3, 2, 1}},
+		"name":           {sortBy: types.StringValue("name"), sortOrder: types.StringValue("asc"), expected: []int64{2, 1, 3}},
+		"name desc":      {sortBy: types.StringValue("name"), sortOrder: types.StringValue("desc"), expected: []int64{3, 1, 2}},
+		"last used":      {sortBy: types.StringValue("last_used"), sortOrder: types.StringNull(), expected: []int64{1, 2, 3}},
+		"last used desc": {sortBy: types.StringValue("last_used"), sortOrder: types.StringValue("desc"), expected: []int64{3, 2, 1}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sorted := append([]sortKey{}, entries...)
+			sortEntries(sorted, func(key sortKey) sortKey { return key }, test.sortBy, test.sortOrder)
+
+			ids := []int64{}
+			for _, key := range sorted {
+				ids = append(ids, key.id)
+			}
+
+			if !reflect.DeepEqual(ids, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, ids)
+			}
+		})
+	}
+}
+
+func TestValidateSort(t *testing.T) {
+	if diags := validateSort(types.StringValue("name"), types.StringValue("desc")); diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+
+	if diags := validateSort(types.StringValue("date"), types.StringValue("down")); diags.ErrorsCount() != 2 {
+		t.Fatalf("expected sort_by and sort_order to be invalid, got %v", diags)
+	}
+}
+
+func TestPageEntries(t *testing.T) {
+	entries := []int{1, 2, 3, 4, 5}
+
+	tests := map[string]struct {
+		offset   types.Int64
+		limit    types.Int64
+		expected []int
+	}{
+		"all":            {offset: types.Int64Null(), limit: types.Int64Null(), expected: []int{1, 2, 3, 4, 5}},
+		"limit":          {offset: types.Int64Null(), limit: types.Int64Value(2), expected: []int{1, 2}},
+		"offset":         {offset: types.Int64Value(3), limit: types.Int64Null(), expected: []int{4, 5}},
+		"page":           {offset: types.Int64Value(2), limit: types.Int64Value(2), expected: []int{3, 4}},
+		"past the end":   {offset: types.Int64Value(10), limit: types.Int64Value(2), expected: []int{}},
+		"limit past end": {offset: types.Int64Value(4), limit: types.Int64Value(10), expected: []int{5}},
+		"largest limit":  {offset: types.Int64Value(1), limit: types.Int64Value(math.MaxInt64), expected: []int{2, 3, 4, 5}},
+		"largest offset": {offset: types.Int64Value(math.MaxInt64), limit: types.Int64Value(math.MaxInt64), expected: []int{}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := pageEntries(entries, test.offset, test.limit); !reflect.DeepEqual(got, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+
+	if diags := validatePage(types.Int64Value(-1), types.Int64Value(0)); len(diags.Errors()) != 2 {
+		t.Fatalf("expected errors for the negative offset and the zero limit, got %v", diags)
+	}
+}
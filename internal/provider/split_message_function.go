@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// splitMessage splits message in parts of at most maxLength characters,
+// each prefixed with a part indicator such as (2/3), to be sent as
+// sequential messages. Parts end at a newline, or else at a space, when one
+// is in their second half. A message short enough is returned as is.
+func splitMessage(message string, maxLength int) ([]string, error) {
+	runes := []rune(message)
+	if len(runes) <= maxLength {
+		return []string{message}, nil
+	}
+
+	// The indicators are as long as the number of parts is, which depends
+	// on the room they leave, so each number of digits is tried in turn.
+	for digits, limit := 1, 10; ; digits, limit = digits+1, limit*10 {
+		room := maxLength - len(fmt.Sprintf("(%0*d/%0*d) ", digits, 0, digits, 0))
+		if room < 1 {
+			return nil, fmt.Errorf("max_length %d leaves no room for the message next to the part indicators", maxLength)
+		}
+
+		chunks := splitRunes(runes, room)
+		if len(chunks) >= limit {
+			continue
+		}
+
+		parts := make([]string, len(chunks))
+		for i, chunk := range chunks {
+			parts[i] = fmt.Sprintf("(%d/%d) %s", i+1, len(chunks), chunk)
+		}
+
+		return parts, nil
+	}
+}
+
+// splitRunes splits runes in chunks of at most room runes, preferably at a
+// newline or a space, which is dropped.
+func splitRunes(runes []rune, room int) []string {
+	var chunks []string
+
+	for len(runes) > room {
+		cut := -1
+		for _, separator := range []rune{'\n', ' '} {
+			for i := room; i >= room/2 && i > 0; i-- {
+				if runes[i] == separator {
+					cut = i
+					break
+				}
+			}
+			if cut >= 0 {
+				break
+			}
+		}
+
+		if cut < 0 {
+			chunks = append(chunks, string(runes[:room]))
+			runes = runes[room:]
+			continue
+		}
+
+		chunks = append(chunks, string(runes[:cut]))
+		runes = runes[cut+1:]
+	}
+
+	// A message ending with a separator has no last chunk.
+	if len(runes) == 0 && len(chunks) > 0 {
+		return chunks
+	}
+
+	return append(chunks, string(runes))
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SplitMessageFunction{}
+
+func NewSplitMessageFunction() function.Function {
+	return &SplitMessageFunction{}
+}
+
+// SplitMessageFunction defines the function implementation.
+type SplitMessageFunction struct{}
+
+func (f *SplitMessageFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "split_message"
+}
+
+func (f *SplitMessageFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Split a long message in parts",
+		MarkdownDescription: "Splits a message longer than `max_length` characters in parts to send as sequential messages, each prefixed with a part indicator such as `(2/3) ` and at most `max_length` characters long, indicator included. Parts end at a newline, or else at a space, when there is one in their second half. A message short enough is returned as the only part, without indicator.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "message",
+				MarkdownDescription: "Message to split, e.g. a changelog",
+			},
+			function.Int64Parameter{
+				Name:                "max_length",
+				MarkdownDescription: "Maximum number of characters of each part",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *SplitMessageFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var message string
+	var maxLength int64
+
+	resp.Diagnostics.Append(req.Arguments.Get(ctx, &message, &maxLength)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parts, err := splitMessage(message, int(maxLength))
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid max_length", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, parts)...)
+}
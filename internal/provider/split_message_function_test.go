@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSplitMessageFunction(t *testing.T) {
+	tests := map[string]struct {
+		message   string
+		maxLength int64
+		expected  []string
+		wantErr   bool
+	}{
+		"short":     {message: "deployed", maxLength: 8, expected: []string{"deployed"}},
+		"newline":   {message: "v1.2.0\nfix login\nfix logout", maxLength: 22, expected: []string{"(1/2) v1.2.0\nfix login", "(2/2) fix logout"}},
+		"space":     {message: "fix login and logout", maxLength: 16, expected: []string{"(1/2) fix login", "(2/2) and logout"}},
+		"hard cut":  {message: "éééééééééééé", maxLength: 10, expected: []string{"(1/3) éééé", "(2/3) éééé", "(3/3) éééé"}},
+		"too short": {message: "deployed", maxLength: 6, wantErr: true},
+		"negative":  {message: "deployed", maxLength: -1, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(test.message),
+					types.Int64Value(test.maxLength),
+				}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.ListUnknown(types.StringType)),
+			}
+
+			NewSplitMessageFunction().Run(context.Background(), req, &resp)
+
+			if test.wantErr {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			elements := make([]attr.Value, len(test.expected))
+			for i, part := range test.expected {
+				elements[i] = types.StringValue(part)
+			}
+
+			if !resp.Result.Equal(function.NewResultData(types.ListValueMust(types.StringType, elements))) {
+				t.Fatalf("expected %v, got %s", test.expected, resp.Result.Value())
+			}
+		})
+	}
+}
+
+func TestSplitMessageManyParts(t *testing.T) {
+	message := strings.Repeat("word ", 40)
+
+	parts, err := splitMessage(message, 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(parts) < 10 {
+		t.Fatalf("expected at least 10 parts, got %d", len(parts))
+	}
+
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) > 12 {
+			t.Errorf("part %q is longer than 12 characters", part)
+		}
+	}
+
+	if !strings.HasPrefix(parts[0], "(1/") || !strings.HasPrefix(parts[len(parts)-1], "(40/40) ") {
+		t.Errorf("unexpected indicators: %q", parts)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TimeoutsModel describes the timeouts of the operations on a resource, and
+// of each call to Gotify they make, as durations such as "30s" or "5m".
+type TimeoutsModel struct {
+	Create  types.String `tfsdk:"create"`
+	Read    types.String `tfsdk:"read"`
+	Update  types.String `tfsdk:"update"`
+	Delete  types.String `tfsdk:"delete"`
+	Request types.String `tfsdk:"request"`
+	Upload  types.String `tfsdk:"upload"`
+}
+
+// RequestTimeoutsModel describes the timeouts of the operations on a
+// resource which doesn't upload files, and of each call to Gotify they make.
+type RequestTimeoutsModel struct {
+	Create  types.String `tfsdk:"create"`
+	Read    types.String `tfsdk:"read"`
+	Update  types.String `tfsdk:"update"`
+	Delete  types.String `tfsdk:"delete"`
+	Request types.String `tfsdk:"request"`
+}
+
+// timeouts returns m as a TimeoutsModel without an upload timeout, nil when
+// m is.
+func (m *RequestTimeoutsModel) timeouts() *TimeoutsModel {
+	if m == nil {
+		return nil
+	}
+
+	return &TimeoutsModel{
+		Create:  m.Create,
+		Read:    m.Read,
+		Update:  m.Update,
+		Delete:  m.Delete,
+		Request: m.Request,
+		Upload:  types.StringNull(),
+	}
+}
+
+// requestTimeoutsBlock returns the schema of the timeouts block of a
+// resource whose operations are on subject, e.g. "the plugin".
+func requestTimeoutsBlock(subject string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: fmt.Sprintf("Timeouts of the operations on %s, overriding the provider `default_timeouts`", subject),
+		Attributes: map[string]schema.Attribute{
+			"create": schema.StringAttribute{
+				MarkdownDescription: "Timeout of the creation, as a duration such as `30s` or `5m`",
+				Optional:            true,
+			},
+			"read": schema.StringAttribute{
+				MarkdownDescription: "Timeout of reads, as a duration such as `30s` or `5m`",
+				Optional:            true,
+			},
+			"update": schema.StringAttribute{
+				MarkdownDescription: "Timeout of updates, as a duration such as `30s` or `5m`",
+				Optional:            true,
+			},
+			"delete": schema.StringAttribute{
+				MarkdownDescription: "Timeout of the deletion, as a duration such as `30s` or `5m`",
+				Optional:            true,
+			},
+			"request": schema.StringAttribute{
+				MarkdownDescription: "Timeout of each call to Gotify, including reading its answer, as a duration such as `30s` or `5m`",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// timeoutOperations are the operations a timeout can be set for.
+var timeoutOperations = []string{"create", "read", "update", "delete"}
+
+// timeoutCalls are the kinds of calls to Gotify a timeout can be set for:
+// uploads, and every other request.
+var timeoutCalls = []string{"request", "upload"}
+
+// uploadOperations are the calls sending files to Gotify, which are bound by
+// the upload timeout instead of the request one.
+var uploadOperations = map[string]bool{
+	"POST /application/{id}/image": true,
+}
+
+// value returns the timeout set for operation, null when m is.
+func (m *TimeoutsModel) value(operation string) types.String {
+	if m == nil {
+		return types.StringNull()
+	}
+
+	switch operation {
+	case "create":
+		return m.Create
+	case "read":
+		return m.Read
+	case "update":
+		return m.Update
+	case "delete":
+		return m.Delete
+	case "request":
+		return m.Request
+	case "upload":
+		return m.Upload
+	}
+
+	return types.StringNull()
+}
+
+// validateTimeouts returns an error for each timeout of m, located at root,
+// which isn't a positive duration.
+func validateTimeouts(m *TimeoutsModel, root path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, operation := range append(timeoutOperations, timeoutCalls...) {
+		value := m.value(operation)
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		duration, err := time.ParseDuration(value.ValueString())
+		if err == nil && duration <= 0 {
+			diags.AddAttributeError(root.AtName(operation), "Invalid timeout", "The timeout must be positive")
+		} else if err != nil {
+			diags.AddAttributeError(root.AtName(operation), "Invalid timeout", err.Error())
+		}
+	}
+
+	return diags
+}
+
+// operationContext returns a context cancelled once the timeout of operation
+// elapses: the one set in timeouts, or else the one set in the provider
+// default_timeouts. Without any, the context is only cancelled with ctx.
+// The timeouts of the calls made with the context are set the same way.
+func (c *GotifyClient) operationContext(ctx context.Context, operation string, timeouts *TimeoutsModel) (context.Context, context.CancelFunc) {
+	ctx = withCallTimeouts(ctx, callTimeouts{
+		request: c.timeout(timeouts, "request"),
+		upload:  c.timeout(timeouts, "upload"),
+	})
+
+	duration := c.timeout(timeouts, operation)
+	if duration == 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, duration)
+}
+
+// timeout returns the timeout of name set in timeouts, or else in the
+// provider default_timeouts, and 0 without any.
+func (c *GotifyClient) timeout(timeouts *TimeoutsModel, name string) time.Duration {
+	value := timeouts.value(name)
+	if value.IsNull() {
+		value = c.Config.DefaultTimeouts.value(name)
+	}
+
+	// Timeouts are validated with the configuration, so they parse here.
+	duration, err := time.ParseDuration(value.ValueString())
+	if value.IsNull() || err != nil {
+		return 0
+	}
+
+	return duration
+}
+
+// callTimeouts bound each call to Gotify made during an operation: uploads
+// can be given longer than other requests.
+type callTimeouts struct {
+	request time.Duration
+	upload  time.Duration
+}
+
+// callTimeoutsKey is the context key of the timeouts of the calls.
+type callTimeoutsKey struct{}
+
+// withCallTimeouts returns a copy of ctx whose calls to Gotify are bound by
+// timeouts.
+func withCallTimeouts(ctx context.Context, timeouts callTimeouts) context.Context {
+	return context.WithValue(ctx, callTimeoutsKey{}, timeouts)
+}
+
+// callTimeout returns the timeout of operation, a call to Gotify made with
+// ctx, and 0 when it isn't bound. Uploads fall back to the request timeout.
+func callTimeout(ctx context.Context, operation string) time.Duration {
+	timeouts, _ := ctx.Value(callTimeoutsKey{}).(callTimeouts)
+	if uploadOperations[operation] && timeouts.upload != 0 {
+		return timeouts.upload
+	}
+
+	return timeouts.request
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateTimeouts(t *testing.T) {
+	timeouts := &TimeoutsModel{
+		Create: types.StringValue("30s"),
+		Read:   types.StringValue("soon"),
+		Update: types.StringValue("-1m"),
+		Delete: types.StringUnknown(),
+	}
+
+	diags := validateTimeouts(timeouts, path.Root("timeouts"))
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected read and update to be invalid, got %v", diags)
+	}
+
+	if diags := validateTimeouts(nil, path.Root("timeouts")); diags.HasError() {
+		t.Fatalf("unexpected errors without timeouts: %v", diags)
+	}
+}
+
+func TestOperationContext(t *testing.T) {
+	client := &GotifyClient{
+		Config: GotifyProviderModel{
+			DefaultTimeouts: &TimeoutsModel{
+				Create: types.StringValue("1h"),
+				Read:   types.StringValue("2h"),
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		operation string
+		timeouts  *TimeoutsModel
+		expected  time.Duration
+	}{
+		"provider default":  {operation: "create", expected: time.Hour},
+		"resource":          {operation: "read", timeouts: &TimeoutsModel{Read: types.StringValue("3h")}, expected: 3 * time.Hour},
+		"other operation":   {operation: "read", timeouts: &TimeoutsModel{Create: types.StringValue("3h")}, expected: 2 * time.Hour},
+		"none":              {operation: "delete"},
+		"request timeouts":  {operation: "update", timeouts: (&RequestTimeoutsModel{Update: types.StringValue("4h")}).timeouts(), expected: 4 * time.Hour},
+		"no plugin timeout": {operation: "create", timeouts: (*RequestTimeoutsModel)(nil).timeouts(), expected: time.Hour},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := client.operationContext(context.Background(), test.operation, test.timeouts)
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			if test.expected == 0 {
+				if ok {
+					t.Fatalf("expected no deadline, got %s", deadline)
+				}
+				return
+			}
+
+			if !ok {
+				t.Fatal("expected a deadline")
+			}
+
+			if remaining := time.Until(deadline); remaining > test.expected || remaining < test.expected-time.Minute {
+				t.Fatalf("expected a deadline in %s, got %s", test.expected, remaining)
+			}
+		})
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	client := &GotifyClient{
+		Config: GotifyProviderModel{
+			DefaultTimeouts: &TimeoutsModel{Request: types.StringValue("1m")},
+		},
+	}
+
+	tests := map[string]struct {
+		timeouts  *TimeoutsModel
+		operation string
+		expected  time.Duration
+	}{
+		"provider default": {operation: "PUT /application/{id}", expected: time.Minute},
+		"upload fallback":  {operation: "POST /application/{id}/image", expected: time.Minute},
+		"resource request": {operation: "PUT /application/{id}", timeouts: &TimeoutsModel{Request: types.StringValue("2m")}, expected: 2 * time.Minute},
+		"resource upload":  {operation: "POST /application/{id}/image", timeouts: &TimeoutsModel{Upload: types.StringValue("5m")}, expected: 5 * time.Minute},
+		"other calls":      {operation: "PUT /application/{id}", timeouts: &TimeoutsModel{Upload: types.StringValue("5m")}, expected: time.Minute},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := client.operationContext(context.Background(), "update", test.timeouts)
+			defer cancel()
+
+			if timeout := callTimeout(ctx, test.operation); timeout != test.expected {
+				t.Fatalf("expected %s, got %s", test.expected, timeout)
+			}
+		})
+	}
+
+	if timeout := callTimeout(context.Background(), "PUT /application/{id}"); timeout != 0 {
+		t.Fatalf("expected no timeout outside of an operation, got %s", timeout)
+	}
+}
@@ -0,0 +1,372 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// gotifyTransport applies the provider connection settings to every request
+// sent to Gotify.
+type gotifyTransport struct {
+	base       http.RoundTripper
+	hostHeader string
+	token      string
+	username   string
+	password   string
+	// bearer is sent as the Authorization: Bearer header, for proxies
+	// authenticating calls before they reach Gotify.
+	bearer string
+	// tokenInQuery sends the token as the token query parameter instead
+	// of the X-Gotify-Key header, for proxies stripping custom headers.
+	tokenInQuery bool
+	// endpoints are the URLs of the Gotify instance, the first one being
+	// the url requests are built with. active is the index of the endpoint
+	// requests are currently sent to.
+	endpoints []string
+	active    atomic.Int32
+	// readOnly refuses any request which could modify Gotify.
+	readOnly bool
+	// logOnly logs the requests which could modify Gotify instead of
+	// sending them, and answers them with synthetic objects.
+	logOnly bool
+	// userAgent is the User-Agent header sent with every request, Go's
+	// default when empty.
+	userAgent string
+	// maxResponseSize is the number of bytes of a response body after
+	// which reading it fails.
+	maxResponseSize int64
+	// cache keeps the answers to GET requests when data_source_cache_ttl
+	// is set, nil otherwise.
+	cache *responseCache
+	// inflight collapses the concurrent identical list requests.
+	inflight *requestGroup
+}
+
+// defaultMaxResponseSize is the maximum size of a response body when
+// max_response_size isn't set.
+const defaultMaxResponseSize = 64 << 20
+
+// wrapTransport wraps the transport sending requests over the network. Tests
+// replace it to record and replay the traffic of acceptance tests.
+var wrapTransport = func(base http.RoundTripper) http.RoundTripper {
+	return base
+}
+
+// newTransport builds the transport used by the provider client from the
+// provider configuration.
+func newTransport(data GotifyProviderModel, endpoints []string, userAgent string) http.RoundTripper {
+	// The default transport sends requests through the proxies set in the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.Proxy = http.ProxyFromEnvironment
+
+	if !data.ProxyFromEnvironment.IsNull() && !data.ProxyFromEnvironment.ValueBool() {
+		base.Proxy = nil
+	}
+
+	if serverName := data.TlsServerName.ValueString(); serverName != "" {
+		base.TLSClientConfig = &tls.Config{
+			ServerName: serverName,
+		}
+	}
+
+	maxResponseSize := int64(defaultMaxResponseSize)
+	if !data.MaxResponseSize.IsNull() {
+		maxResponseSize = data.MaxResponseSize.ValueInt64()
+	}
+
+	var cache *responseCache
+	if ttl, err := time.ParseDuration(data.DataSourceCacheTtl.ValueString()); err == nil && ttl > 0 {
+		cache = newResponseCache(ttl)
+	}
+
+	roundTripper := wrapTransport(base)
+	if data.Mock.ValueBool() {
+		dir, _ := os.Getwd()
+		roundTripper = &handlerTransport{handler: mockGotify(mockStatePath(dir))}
+	}
+
+	return &gotifyTransport{
+		base:       roundTripper,
+		hostHeader: data.HostHeader.ValueString(),
+		token:      data.Token.ValueString(),
+		username:   data.Username.ValueString(),
+		password:   data.Password.ValueString(),
+		bearer:     data.AuthorizationBearer.ValueString(),
+
+		tokenInQuery: data.TokenInQuery.ValueBool(),
+		endpoints:    endpoints,
+		readOnly:     data.ReadOnly.ValueBool(),
+		logOnly:      data.LogOnly.ValueBool(),
+
+		userAgent:       userAgent,
+		maxResponseSize: maxResponseSize,
+		cache:           cache,
+		inflight:        newRequestGroup(),
+	}
+}
+
+// userAgent returns the User-Agent header identifying the provider version
+// and the Terraform version it runs with, followed by extra when set.
+func userAgent(terraformVersion string, providerVersion string, extra string) string {
+	userAgent := fmt.Sprintf("Terraform/%s terraform-provider-gotify/%s", terraformVersion, providerVersion)
+	if extra != "" {
+		userAgent = fmt.Sprintf("%s %s", userAgent, extra)
+	}
+
+	return userAgent
+}
+
+// redactURL returns u as a string with the value of the token query
+// parameter masked, so it can safely be logged.
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	if !query.Has("token") {
+		return u.String()
+	}
+
+	query.Set("token", "REDACTED")
+	redacted := *u
+	redacted.RawQuery = query.Encode()
+
+	return redacted.String()
+}
+
+func (t *gotifyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.readOnly && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return nil, fmt.Errorf("refusing %s request, the provider is configured with read_only = true", req.Method)
+	}
+
+	if t.logOnly && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		// Changes clear the cache whether they are sent or only logged.
+		if t.cache != nil {
+			t.cache.clear()
+		}
+		return logOnlyRoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the request it was given.
+	req = req.Clone(req.Context())
+
+	if t.hostHeader != "" {
+		req.Host = t.hostHeader
+	}
+
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+
+	if t.bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+t.bearer)
+	}
+
+	if name, ok := moduleNameFromContext(req.Context()); ok {
+		req.Header.Set(managedByHeader, name)
+	}
+
+	creds := credentials{token: t.token, username: t.username, password: t.password}
+	if owner, ok := credentialsFromContext(req.Context()); ok {
+		creds = owner
+	}
+
+	// Basic auth is used for every call when credentials are configured,
+	// otherwise the client token authenticates the request.
+	if creds.username != "" {
+		req.SetBasicAuth(creds.username, creds.password)
+	} else if creds.token != "" && t.tokenInQuery {
+		// The token is only added to the clone so errors returned by the
+		// client, which include the original URL, never contain it.
+		query := req.URL.Query()
+		query.Set("token", creds.token)
+		req.URL.RawQuery = query.Encode()
+	} else if creds.token != "" {
+		req.Header.Set("X-Gotify-Key", creds.token)
+	}
+
+	key := cacheKey(req, creds)
+	if t.cache != nil && req.Method == http.MethodGet {
+		if res, ok := t.cache.get(req, key); ok {
+			tflog.Debug(req.Context(), fmt.Sprintf("Reusing the cached answer to GET %s", redactURL(req.URL)))
+			return res, nil
+		}
+	} else if t.cache != nil {
+		t.cache.clear()
+	}
+
+	operation := callOperation(req, t.basePath())
+	if t.inflight != nil && listOperations[operation] {
+		return t.inflight.do(req, key, func() (*http.Response, error) {
+			return t.send(req, key, operation)
+		})
+	}
+
+	return t.send(req, key, operation)
+}
+
+// send sends req, whose cache key is key, to Gotify and records the duration
+// of operation.
+func (t *gotifyTransport) send(req *http.Request, key string, operation string) (*http.Response, error) {
+	start := time.Now()
+	defer func() { callStats.record(operation, time.Since(start)) }()
+
+	// A change made while the request is sent may not be seen by its answer.
+	var generation uint64
+	if t.cache != nil {
+		generation = t.cache.currentGeneration()
+	}
+
+	// The deadline of the call also bounds reading the answer, so it is
+	// only cancelled once the body is closed.
+	cancel := context.CancelFunc(func() {})
+	if timeout := callTimeout(req.Context(), operation); timeout != 0 {
+		var ctx context.Context
+		ctx, cancel = context.WithTimeout(req.Context(), timeout)
+		req = req.WithContext(ctx)
+	}
+
+	var res *http.Response
+	var err error
+	if len(t.endpoints) > 1 {
+		res, err = t.roundTripWithFailover(req)
+	} else {
+		res, err = t.base.RoundTrip(req)
+	}
+
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	res.Body = &limitedBody{body: res.Body, remaining: t.maxResponseSize, limit: t.maxResponseSize, cancel: cancel}
+
+	if t.cache != nil && req.Method == http.MethodGet {
+		return t.cache.put(key, generation, res)
+	}
+
+	return res, nil
+}
+
+// limitedBody fails reads once more than limit bytes were read, so a
+// runaway response can't exhaust the memory of the provider. Closing it
+// cancels the deadline of the call.
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	limit     int64
+	cancel    context.CancelFunc
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, fmt.Errorf("response body exceeds max_response_size of %d bytes", b.limit)
+	}
+
+	// Read one byte more than allowed to tell a body of exactly limit bytes
+	// from a larger one.
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), fmt.Errorf("response body exceeds max_response_size of %d bytes", b.limit)
+	}
+
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	err := b.body.Close()
+	b.cancel()
+
+	return err
+}
+
+// roundTripWithFailover sends req to the active endpoint. When the endpoint
+// can't be reached, the next one becomes active for subsequent calls, and
+// idempotent requests are retried against it straight away.
+func (t *gotifyTransport) roundTripWithFailover(req *http.Request) (*http.Response, error) {
+	primary := t.endpoints[0]
+	path := strings.TrimPrefix(req.URL.String(), primary)
+
+	var err error
+	for attempt := 0; attempt < len(t.endpoints); attempt++ {
+		active := int(t.active.Load())
+
+		attemptReq := req.Clone(req.Context())
+		if active != 0 {
+			attemptReq.URL, err = url.Parse(t.endpoints[active] + path)
+			if err != nil {
+				return nil, err
+			}
+
+			if t.hostHeader == "" {
+				attemptReq.Host = ""
+			}
+		}
+
+		if attempt > 0 && req.GetBody != nil {
+			attemptReq.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		var res *http.Response
+		res, err = t.base.RoundTrip(attemptReq)
+		if err == nil {
+			return res, nil
+		}
+
+		next := (active + 1) % len(t.endpoints)
+		if t.active.CompareAndSwap(int32(active), int32(next)) {
+			tflog.Warn(req.Context(), fmt.Sprintf("Gotify endpoint %s unreachable, failing over to %s", t.endpoints[active], t.endpoints[next]))
+		}
+
+		if !isIdempotent(req.Method) || (req.Body != nil && req.GetBody == nil) {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+// basePath returns the path Gotify is served under, such as /gotify when
+// it runs behind a reverse proxy.
+func (t *gotifyTransport) basePath() string {
+	if len(t.endpoints) == 0 {
+		return ""
+	}
+
+	parsed, err := url.Parse(t.endpoints[0])
+	if err != nil {
+		return ""
+	}
+
+	return parsed.Path
+}
+
+// isIdempotent reports whether a request with the given method can safely be
+// sent again after a failure.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,685 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTransportHostHeader(t *testing.T) {
+	var host string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: newTransport(GotifyProviderModel{
+			HostHeader: types.StringValue("gotify.example.com"),
+		}, nil, ""),
+	}
+
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if host != "gotify.example.com" {
+		t.Fatalf("expected host header gotify.example.com, got %s", host)
+	}
+}
+
+func TestTransportAuthentication(t *testing.T) {
+	var header http.Header
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		query = r.URL.Query()
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		config     GotifyProviderModel
+		wantKey    string
+		wantBasic  bool
+		wantUser   string
+		wantPasswd string
+		wantQuery  string
+		wantBearer string
+	}{
+		"token": {
+			config:  GotifyProviderModel{Token: types.StringValue("CToken")},
+			wantKey: "CToken",
+		},
+		"token in query": {
+			config: GotifyProviderModel{
+				Token:        types.StringValue("CToken"),
+				TokenInQuery: types.BoolValue(true),
+			},
+			wantQuery: "CToken",
+		},
+		"basic auth": {
+			config: GotifyProviderModel{
+				Token:    types.StringValue("CToken"),
+				Username: types.StringValue("admin"),
+				Password: types.StringValue("secret"),
+			},
+			wantBasic:  true,
+			wantUser:   "admin",
+			wantPasswd: "secret",
+		},
+		"bearer": {
+			config: GotifyProviderModel{
+				Token:               types.StringValue("CToken"),
+				AuthorizationBearer: types.StringValue("proxy-token"),
+			},
+			wantKey:    "CToken",
+			wantBearer: "Bearer proxy-token",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &http.Client{Transport: newTransport(test.config, nil, "")}
+
+			res, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+
+			if got := header.Get("X-Gotify-Key"); got != test.wantKey {
+				t.Fatalf("expected X-Gotify-Key %q, got %q", test.wantKey, got)
+			}
+
+			if got := query.Get("token"); got != test.wantQuery {
+				t.Fatalf("expected token query parameter %q, got %q", test.wantQuery, got)
+			}
+
+			if !test.wantBasic {
+				if got := header.Get("Authorization"); got != test.wantBearer {
+					t.Fatalf("expected Authorization %q, got %q", test.wantBearer, got)
+				}
+			}
+
+			req := &http.Request{Header: header}
+			username, password, ok := req.BasicAuth()
+			if ok != test.wantBasic || username != test.wantUser || password != test.wantPasswd {
+				t.Fatalf("unexpected basic auth %q:%q (%t)", username, password, ok)
+			}
+		})
+	}
+}
+
+func TestTransportOwnerCredentials(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: newTransport(GotifyProviderModel{Token: types.StringValue("AdminToken")}, nil, ""),
+	}
+
+	ctx := withCredentials(context.Background(), credentials{username: "team-payments", password: "secret"})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/application", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if got := header.Get("X-Gotify-Key"); got != "" {
+		t.Fatalf("expected the provider token not to be sent, got %q", got)
+	}
+
+	username, password, ok := (&http.Request{Header: header}).BasicAuth()
+	if !ok || username != "team-payments" || password != "secret" {
+		t.Fatalf("expected the owner credentials, got %q:%q (%t)", username, password, ok)
+	}
+}
+
+func TestTransportModuleName(t *testing.T) {
+	ctx := context.Background()
+
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: newTransport(GotifyProviderModel{Token: types.StringValue("AdminToken")}, nil, ""),
+	}
+
+	metaResp := &provider.MetaSchemaResponse{}
+	(&GotifyProvider{}).MetaSchema(ctx, provider.MetaSchemaRequest{}, metaResp)
+
+	for name, expected := range map[string]string{"": "", "monitoring": "monitoring"} {
+		meta := tfsdk.Config{Schema: metaResp.Schema, Raw: tftypes.NewValue(metaResp.Schema.Type().TerraformType(ctx), nil)}
+		if name != "" {
+			meta.Raw = tftypes.NewValue(metaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+				"module_name": tftypes.NewValue(tftypes.String, name),
+			})
+		}
+
+		req, err := http.NewRequestWithContext(moduleContext(ctx, meta), http.MethodGet, server.URL+"/application", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if got := header.Get(managedByHeader); got != expected {
+			t.Fatalf("expected %s header %q, got %q", managedByHeader, expected, got)
+		}
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	u, err := url.Parse("https://gotify.example.com/application?token=CToken&limit=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redacted := redactURL(u)
+	if strings.Contains(redacted, "CToken") {
+		t.Fatalf("token not redacted in %s", redacted)
+	}
+
+	if u.Query().Get("token") != "CToken" {
+		t.Fatal("redactURL modified its argument")
+	}
+}
+
+func TestTransportFailover(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	down.Close()
+
+	var calls int
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer up.Close()
+
+	client := &http.Client{
+		Transport: newTransport(GotifyProviderModel{}, []string{down.URL, up.URL}, ""),
+	}
+
+	// GET requests are retried against the next URL.
+	res, err := client.Get(down.URL + "/application")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	// POST requests aren't retried, but are sent to the reachable URL once
+	// the failover happened.
+	res, err = client.Post(down.URL+"/application", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if calls != 2 {
+		t.Fatalf("expected 2 calls to the reachable URL, got %d", calls)
+	}
+}
+
+func TestTransportCallTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: newTransport(GotifyProviderModel{}, nil, ""),
+	}
+
+	ctx := withCallTimeouts(context.Background(), callTimeouts{request: 20 * time.Millisecond, upload: 5 * time.Second})
+
+	// The metadata update doesn't answer within the request timeout.
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, server.URL+"/application/1", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.Do(req)
+	if err == nil {
+		res.Body.Close()
+		t.Fatal("expected the update to time out")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline error, got %v", err)
+	}
+
+	// The image upload is given the longer upload timeout.
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/application/1/image", strings.NewReader("image"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(res.Body); err != nil {
+		t.Fatalf("unexpected error reading the answer: %s", err)
+	}
+	res.Body.Close()
+}
+
+func TestTransportProxyFromEnvironment(t *testing.T) {
+	tests := map[string]struct {
+		config    GotifyProviderModel
+		wantProxy bool
+	}{
+		"default":  {config: GotifyProviderModel{}, wantProxy: true},
+		"enabled":  {config: GotifyProviderModel{ProxyFromEnvironment: types.BoolValue(true)}, wantProxy: true},
+		"disabled": {config: GotifyProviderModel{ProxyFromEnvironment: types.BoolValue(false)}, wantProxy: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			transport := newTransport(test.config, nil, "").(*gotifyTransport)
+
+			if got := transport.base.(*http.Transport).Proxy != nil; got != test.wantProxy {
+				t.Fatalf("expected proxy from environment to be %t, got %t", test.wantProxy, got)
+			}
+		})
+	}
+}
+
+func TestTransportReadOnly(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: newTransport(GotifyProviderModel{ReadOnly: types.BoolValue(true)}, nil, ""),
+	}
+
+	res, err := client.Get(server.URL + "/application")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	_, err = client.Post(server.URL+"/application", "application/json", strings.NewReader("{}"))
+	if err == nil || !strings.Contains(err.Error(), "read_only") {
+		t.Fatalf("expected a read-only error, got %v", err)
+	}
+
+	if len(methods) != 1 || methods[0] != http.MethodGet {
+		t.Fatalf("expected only the GET request to be sent, got %v", methods)
+	}
+}
+
+func TestTransportLogOnly(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: newTransport(GotifyProviderModel{LogOnly: types.BoolValue(true)}, nil, ""),
+	}
+
+	res, err := client.Get(server.URL + "/application")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	tests := map[string]struct {
+		method   string
+		path     string
+		payload  string
+		expected func(answer map[string]interface{}) bool
+	}{
+		"create": {
+			method:  http.MethodPost,
+			path:    "/application",
+			payload: `{"name":"alerts","defaultPriority":5}`,
+			expected: func(answer map[string]interface{}) bool {
+				return answer["id"].(float64) >= logOnlyFirstID && answer["name"] == "alerts" && answer["token"] != ""
+			},
+		},
+		"update": {
+			method:  http.MethodPut,
+			path:    "/application/42",
+			payload: `{"name":"alerts"}`,
+			expected: func(answer map[string]interface{}) bool {
+				return answer["id"].(float64) == 42 && answer["name"] == "alerts"
+			},
+		},
+		"delete": {
+			method: http.MethodDelete,
+			path:   "/application/42",
+			expected: func(answer map[string]interface{}) bool {
+				return answer["id"].(float64) == 42
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, server.URL+test.path, strings.NewReader(test.payload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			res, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			var answer map[string]interface{}
+			if err := json.NewDecoder(res.Body).Decode(&answer); err != nil {
+				t.Fatal(err)
+			}
+
+			if res.StatusCode != http.StatusOK || !test.expected(answer) {
+				t.Fatalf("unexpected answer %d %v", res.StatusCode, answer)
+			}
+		})
+	}
+
+	if len(methods) != 1 || methods[0] != http.MethodGet {
+		t.Fatalf("expected only the GET request to be sent, got %v", methods)
+	}
+}
+
+func TestTransportRequestGroup(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	arrived := make(chan struct{}, 10)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+
+		arrived <- struct{}{}
+		<-release
+		_, _ = w.Write([]byte(`[{"id":1}]`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: newTransport(GotifyProviderModel{}, nil, ""),
+	}
+
+	paths := []string{"/application", "/application", "/application", "/application/1", "/application/1"}
+	bodies := make([]string, len(paths))
+	errs := make([]error, len(paths))
+
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		wg.Add(1)
+		go func(i int, path string) {
+			defer wg.Done()
+
+			res, err := client.Get(server.URL + path)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			bodies[i], errs[i] = string(body), err
+		}(i, path)
+	}
+
+	// The list request is sent once, the requests of single objects aren't
+	// grouped.
+	for i := 0; i < 3; i++ {
+		<-arrived
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := range paths {
+		if errs[i] != nil || bodies[i] != `[{"id":1}]` {
+			t.Fatalf("unexpected answer to request %d: %q, %v", i, bodies[i], errs[i])
+		}
+	}
+
+	if requests["/application"] != 1 || requests["/application/1"] != 2 {
+		t.Fatalf("expected a single list request and two requests of the application, got %v", requests)
+	}
+}
+
+func TestTransportMaxResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("a", 100)))
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		maxResponseSize int64
+		wantErr         bool
+	}{
+		"larger":  {maxResponseSize: 1000},
+		"exact":   {maxResponseSize: 100},
+		"smaller": {maxResponseSize: 10, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &http.Client{
+				Transport: newTransport(GotifyProviderModel{MaxResponseSize: types.Int64Value(test.maxResponseSize)}, nil, ""),
+			}
+
+			res, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			if test.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "max_response_size") {
+					t.Fatalf("expected a max_response_size error, got %v", err)
+				}
+				return
+			}
+
+			if err != nil || len(body) != 100 {
+				t.Fatalf("expected the whole body, got %d bytes and %v", len(body), err)
+			}
+		})
+	}
+}
+
+func TestTransportUserAgent(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("User-Agent")
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		extra    string
+		expected string
+	}{
+		"without extra": {expected: "Terraform/1.7.0 terraform-provider-gotify/1.2.3"},
+		"with extra":    {extra: "team-sre pipeline/42", expected: "Terraform/1.7.0 terraform-provider-gotify/1.2.3 team-sre pipeline/42"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &http.Client{
+				Transport: newTransport(GotifyProviderModel{}, nil, userAgent("1.7.0", "1.2.3", test.extra)),
+			}
+
+			res, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+
+			if header != test.expected {
+				t.Fatalf("expected User-Agent %q, got %q", test.expected, header)
+			}
+		})
+	}
+}
+
+func TestTransportDataSourceCache(t *testing.T) {
+	tests := map[string]struct {
+		logOnly bool
+	}{
+		"sent":        {},
+		"logged only": {logOnly: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			testTransportDataSourceCache(t, test.logOnly)
+		})
+	}
+}
+
+func testTransportDataSourceCache(t *testing.T, logOnly bool) {
+	var gets int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets++
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: newTransport(GotifyProviderModel{DataSourceCacheTtl: types.StringValue("1m"), LogOnly: types.BoolValue(logOnly)}, nil, ""),
+	}
+
+	get := func() {
+		res, err := client.Get(server.URL + "/application")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+
+		if body, _ := io.ReadAll(res.Body); string(body) != "[]" {
+			t.Fatalf("unexpected body %q", body)
+		}
+	}
+
+	get()
+	get()
+	if gets != 1 {
+		t.Fatalf("expected the second read to be cached, got %d requests", gets)
+	}
+
+	res, err := client.Post(server.URL+"/application", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	get()
+	if gets != 2 {
+		t.Fatalf("expected the cache to be cleared by the change, got %d requests", gets)
+	}
+}
+
+// TestTransportDataSourceCacheInterleaved checks the answer to a read sent
+// before a change isn't cached once the change was made.
+func TestTransportDataSourceCacheInterleaved(t *testing.T) {
+	fake := newFakeGotify()
+
+	// The first list is answered with the applications before the change,
+	// and only delivered once the change was made.
+	var gets int32
+	listed, release := make(chan struct{}), make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || atomic.AddInt32(&gets, 1) != 1 {
+			fake.ServeHTTP(w, r)
+			return
+		}
+
+		recorder := httptest.NewRecorder()
+		fake.ServeHTTP(recorder, r)
+		close(listed)
+		<-release
+
+		w.WriteHeader(recorder.Code)
+		_, _ = w.Write(recorder.Body.Bytes())
+	})
+
+	transport := newTransport(GotifyProviderModel{DataSourceCacheTtl: types.StringValue("1m")}, nil, "")
+	transport.(*gotifyTransport).base = &handlerTransport{handler: handler}
+	client := &http.Client{Transport: transport}
+
+	list := func() []fakeApplication {
+		res, err := client.Get(mockUrl + "/application")
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+		defer res.Body.Close()
+
+		var applications []fakeApplication
+		if err := json.NewDecoder(res.Body).Decode(&applications); err != nil {
+			t.Error(err)
+		}
+
+		return applications
+	}
+
+	done := make(chan []fakeApplication)
+	go func() { done <- list() }()
+	<-listed
+
+	res, err := client.Post(mockUrl+"/application", "application/json", strings.NewReader(`{"name":"alerts"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	close(release)
+	if stale := <-done; len(stale) != 0 {
+		t.Fatalf("expected the first list to be answered before the change, got %v", stale)
+	}
+
+	if applications := list(); len(applications) != 1 || atomic.LoadInt32(&gets) != 2 {
+		t.Fatalf("expected the answer from before the change not to be cached, got %v after %d lists", applications, gets)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// ansiEscape matches ANSI escape sequences, such as terminal colors.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b[@-_]`)
+
+// truncateMessage removes ANSI escape sequences and control characters other
+// than newlines and tabs from message, then truncates it to at most
+// maxLength characters, ellipsis included.
+func truncateMessage(message string, maxLength int, ellipsis string) string {
+	message = ansiEscape.ReplaceAllString(message, "")
+	message = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\n' && r != '\t' {
+			return -1
+		}
+		return r
+	}, message)
+
+	runes := []rune(message)
+	if len(runes) <= maxLength {
+		return message
+	}
+
+	ellipsisRunes := []rune(ellipsis)
+	if len(ellipsisRunes) >= maxLength {
+		return string(runes[:maxLength])
+	}
+
+	return string(runes[:maxLength-len(ellipsisRunes)]) + ellipsis
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &TruncateMessageFunction{}
+
+func NewTruncateMessageFunction() function.Function {
+	return &TruncateMessageFunction{}
+}
+
+// TruncateMessageFunction defines the function implementation.
+type TruncateMessageFunction struct{}
+
+func (f *TruncateMessageFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "truncate_message"
+}
+
+func (f *TruncateMessageFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Sanitize and truncate a message",
+		MarkdownDescription: "Removes ANSI escape sequences (e.g. terminal colors) and control characters other than newlines and tabs from a message, then truncates it to `max_length` characters, `ellipsis` included.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "message",
+				MarkdownDescription: "Message to sanitize, e.g. the output of a command",
+			},
+			function.Int64Parameter{
+				Name:                "max_length",
+				MarkdownDescription: "Maximum number of characters of the result",
+			},
+			function.StringParameter{
+				Name:                "ellipsis",
+				MarkdownDescription: "Suffix replacing the end of truncated messages, e.g. `...`, or an empty string",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *TruncateMessageFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var message, ellipsis string
+	var maxLength int64
+
+	resp.Diagnostics.Append(req.Arguments.Get(ctx, &message, &maxLength, &ellipsis)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if maxLength < 0 {
+		resp.Diagnostics.AddError("Invalid max_length", "max_length can't be negative")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, truncateMessage(message, int(maxLength), ellipsis))...)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestTruncateMessage(t *testing.T) {
+	tests := map[string]struct {
+		message   string
+		maxLength int
+		ellipsis  string
+		expected  string
+	}{
+		"short":          {message: "deployed", maxLength: 20, ellipsis: "...", expected: "deployed"},
+		"exact length":   {message: "deployed", maxLength: 8, ellipsis: "...", expected: "deployed"},
+		"truncated":      {message: "deployment failed", maxLength: 10, ellipsis: "...", expected: "deploym..."},
+		"no ellipsis":    {message: "deployment failed", maxLength: 10, expected: "deployment"},
+		"long ellipsis":  {message: "deployment failed", maxLength: 2, ellipsis: "...", expected: "de"},
+		"multibyte":      {message: "déploiement échoué", maxLength: 5, ellipsis: "…", expected: "dépl…"},
+		"ansi colors":    {message: "\x1b[31mfailed\x1b[0m", maxLength: 20, expected: "failed"},
+		"control chars":  {message: "line one\r\nline\ttwo\x07", maxLength: 20, expected: "line one\nline\ttwo"},
+		"ansi truncated": {message: "\x1b[1;32mok\x1b[0m then more", maxLength: 4, expected: "ok t"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := truncateMessage(test.message, test.maxLength, test.ellipsis); got != test.expected {
+				t.Fatalf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
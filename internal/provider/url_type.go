@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ basetypes.StringTypable = URLType{}
+var _ xattr.TypeWithValidate = URLType{}
+var _ basetypes.StringValuableWithSemanticEquals = URLValue{}
+
+// URLType is a string type for http and https URLs, whose values are equal
+// when they only differ by a trailing slash or the case of the scheme and
+// host.
+type URLType struct {
+	basetypes.StringType
+}
+
+func (t URLType) Equal(o attr.Type) bool {
+	other, ok := o.(URLType)
+
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t URLType) String() string {
+	return "URLType"
+}
+
+func (t URLType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return URLValue{StringValue: in}, nil
+}
+
+func (t URLType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return URLValue{StringValue: stringValue}, nil
+}
+
+func (t URLType) ValueType(ctx context.Context) attr.Value {
+	return URLValue{}
+}
+
+func (t URLType) Validate(ctx context.Context, in tftypes.Value, valuePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !in.IsKnown() || in.IsNull() {
+		return diags
+	}
+
+	var value string
+	if err := in.As(&value); err != nil {
+		diags.AddAttributeError(valuePath, "Invalid URL", err.Error())
+		return diags
+	}
+
+	if err := validateURL(value); err != nil {
+		diags.AddAttributeError(valuePath, "Invalid URL", err.Error())
+	}
+
+	return diags
+}
+
+// URLValue is a value of URLType.
+type URLValue struct {
+	basetypes.StringValue
+}
+
+// NewURLValue returns a known URLValue holding value.
+func NewURLValue(value string) URLValue {
+	return URLValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v URLValue) Equal(o attr.Value) bool {
+	other, ok := o.(URLValue)
+
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v URLValue) Type(ctx context.Context) attr.Type {
+	return URLType{}
+}
+
+func (v URLValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(URLValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+
+		return false, diags
+	}
+
+	return normalizeURL(v.ValueString()) == normalizeURL(newValue.ValueString()), diags
+}
+
+// validateURL returns an error unless value is an absolute http or https URL.
+func validateURL(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+
+	if scheme := strings.ToLower(parsed.Scheme); scheme != "http" && scheme != "https" {
+		return fmt.Errorf("%q must start with http:// or https://", value)
+	}
+
+	if parsed.Host == "" {
+		return fmt.Errorf("%q has no host", value)
+	}
+
+	return nil
+}
+
+// normalizeURL lowercases the scheme and host of value and removes the
+// trailing slash of its path. Values which don't parse are left as is.
+func normalizeURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return value
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.RawPath = ""
+
+	return parsed.String()
+}
+
+// urlPath returns the path of the URL value, such as /gotify when Gotify
+// runs behind a reverse proxy.
+func urlPath(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSuffix(parsed.Path, "/")
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestURLValueSemanticEquals(t *testing.T) {
+	tests := map[string]struct {
+		old      string
+		new      string
+		expected bool
+	}{
+		"identical":      {old: "https://gotify.example.com", new: "https://gotify.example.com", expected: true},
+		"trailing slash": {old: "https://gotify.example.com", new: "https://gotify.example.com/", expected: true},
+		"host case":      {old: "https://gotify.example.com", new: "HTTPS://Gotify.Example.com", expected: true},
+		"sub path":       {old: "https://example.com/gotify/", new: "https://example.com/gotify", expected: true},
+		"path case":      {old: "https://example.com/gotify", new: "https://example.com/Gotify", expected: false},
+		"other host":     {old: "https://gotify.example.com", new: "https://push.example.com", expected: false},
+		"other scheme":   {old: "https://gotify.example.com", new: "http://gotify.example.com", expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := NewURLValue(test.old).StringSemanticEquals(context.Background(), NewURLValue(test.new))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if equal != test.expected {
+				t.Fatalf("expected %t, got %t", test.expected, equal)
+			}
+		})
+	}
+}
+
+func TestURLTypeValidate(t *testing.T) {
+	tests := map[string]struct {
+		value   tftypes.Value
+		wantErr bool
+	}{
+		"https":     {value: tftypes.NewValue(tftypes.String, "https://gotify.example.com")},
+		"http port": {value: tftypes.NewValue(tftypes.String, "http://localhost:8080")},
+		"null":      {value: tftypes.NewValue(tftypes.String, nil)},
+		"unknown":   {value: tftypes.NewValue(tftypes.String, tftypes.UnknownValue)},
+		"no scheme": {value: tftypes.NewValue(tftypes.String, "gotify.example.com"), wantErr: true},
+		"ftp":       {value: tftypes.NewValue(tftypes.String, "ftp://gotify.example.com"), wantErr: true},
+		"no host":   {value: tftypes.NewValue(tftypes.String, "https:///message"), wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := URLType{}.Validate(context.Background(), test.value, path.Root("url"))
+			if diags.HasError() != test.wantErr {
+				t.Fatalf("expected error %t, got %v", test.wantErr, diags)
+			}
+		})
+	}
+}
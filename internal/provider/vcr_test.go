@@ -0,0 +1,330 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// vcrCassette holds the traffic recorded during a test.
+type vcrCassette struct {
+	Url          string           `json:"url"`
+	Interactions []vcrInteraction `json:"interactions"`
+}
+
+// vcrInteraction is a recorded request and the response Gotify answered.
+type vcrInteraction struct {
+	Method         string      `json:"method"`
+	Path           string      `json:"path"`
+	Status         int         `json:"status"`
+	ResponseHeader http.Header `json:"response_header"`
+	ResponseBody   string      `json:"response_body"`
+
+	replayed bool
+}
+
+// vcrTransport records the traffic sent through base, or replays the
+// traffic of a cassette when base is nil.
+type vcrTransport struct {
+	base     http.RoundTripper
+	mu       sync.Mutex
+	cassette *vcrCassette
+}
+
+func (v *vcrTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Paths are recorded without the instance URL, so the cassette replays
+	// whatever URL the provider is configured with, and without the token
+	// sent with token_in_query.
+	path := strings.TrimPrefix(redactURL(req.URL), v.cassette.Url)
+
+	if v.base == nil {
+		return v.replay(req, path)
+	}
+
+	res, err := v.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.cassette.Interactions = append(v.cassette.Interactions, vcrInteraction{
+		Method:         req.Method,
+		Path:           path,
+		Status:         res.StatusCode,
+		ResponseHeader: res.Header,
+		ResponseBody:   string(body),
+	})
+
+	return res, nil
+}
+
+// replay answers the first interaction of the cassette matching the method
+// and path of req which wasn't replayed yet.
+func (v *vcrTransport) replay(req *http.Request, path string) (*http.Response, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for i := range v.cassette.Interactions {
+		interaction := &v.cassette.Interactions[i]
+		if interaction.replayed || interaction.Method != req.Method || interaction.Path != path {
+			continue
+		}
+		interaction.replayed = true
+
+		return interaction.response(req), nil
+	}
+
+	// Terraform reads data sources a different number of times across its
+	// versions, so reads replay their last answer once all were replayed.
+	if req.Method == http.MethodGet {
+		for i := len(v.cassette.Interactions) - 1; i >= 0; i-- {
+			if interaction := v.cassette.Interactions[i]; interaction.Method == req.Method && interaction.Path == path {
+				return interaction.response(req), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no recorded interaction left for %s %s", req.Method, path)
+}
+
+// response is the recorded response answered to req.
+func (i vcrInteraction) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", i.Status, http.StatusText(i.Status)),
+		StatusCode: i.Status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     i.ResponseHeader.Clone(),
+		Body:       io.NopCloser(strings.NewReader(i.ResponseBody)),
+		Request:    req,
+	}
+}
+
+// testAccVCR records the traffic of the calling acceptance test to
+// testdata/fixtures/<name>.json when GOTIFY_VCR is set to record, and
+// replays it when GOTIFY_VCR is set to replay, so the test runs without a
+// Gotify instance. Tests using it must not run in parallel.
+func testAccVCR(t *testing.T, name string) {
+	mode := os.Getenv("GOTIFY_VCR")
+	if mode == "" {
+		return
+	}
+
+	path := filepath.Join("testdata", "fixtures", name+".json")
+	transport := &vcrTransport{cassette: &vcrCassette{}}
+
+	switch mode {
+	case "record":
+		transport.cassette.Url = os.Getenv("GOTIFY_URL")
+
+		t.Cleanup(func() {
+			if err := saveCassette(path, transport.cassette); err != nil {
+				t.Errorf("can't save cassette: %s", err)
+			}
+		})
+	case "replay":
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("can't read cassette: %s", err)
+		}
+
+		if err := json.Unmarshal(content, transport.cassette); err != nil {
+			t.Fatalf("can't decode cassette %s: %s", path, err)
+		}
+
+		t.Setenv("GOTIFY_URL", transport.cassette.Url)
+		t.Setenv("GOTIFY_TOKEN", "replayed")
+	default:
+		t.Fatalf("GOTIFY_VCR must be record or replay, got %q", mode)
+	}
+
+	previous := wrapTransport
+	wrapTransport = func(base http.RoundTripper) http.RoundTripper {
+		if mode == "record" {
+			transport.base = base
+		}
+
+		return transport
+	}
+	t.Cleanup(func() { wrapTransport = previous })
+}
+
+// saveCassette writes cassette to path, with the tokens Gotify answered,
+// e.g. those of the applications listed, redacted so cassettes can be
+// committed.
+func saveCassette(path string, cassette *vcrCassette) error {
+	redacted := &vcrCassette{Url: cassette.Url}
+	for _, interaction := range cassette.Interactions {
+		header := http.Header{}
+		for key, values := range interaction.ResponseHeader {
+			for _, value := range values {
+				header.Add(key, redactTokens(value))
+			}
+		}
+
+		interaction.ResponseHeader = header
+		interaction.ResponseBody = redactTokens(interaction.ResponseBody)
+		redacted.Interactions = append(redacted.Interactions, interaction)
+	}
+
+	content, err := json.MarshalIndent(redacted, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0o644)
+}
+
+func TestVCRRecordReplay(t *testing.T) {
+	fake := newFakeGotify()
+	recorder := &vcrTransport{
+		base:     &handlerTransport{handler: fake},
+		cassette: &vcrCassette{Url: mockUrl},
+	}
+
+	client := &http.Client{Transport: recorder}
+	for _, name := range []string{"first", "second"} {
+		res, err := client.Post(mockUrl+"/application", "application/json", strings.NewReader(fmt.Sprintf(`{"name":%q}`, name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := saveCassette(path, recorder.cassette); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The tokens of the created applications aren't saved.
+	for _, application := range fake.applications {
+		if strings.Contains(string(content), application.Token) {
+			t.Fatalf("expected the token %s to be redacted from the cassette", application.Token)
+		}
+	}
+
+	// The cassette replays against another URL, in the recorded order.
+	cassette := &vcrCassette{}
+	if err := json.Unmarshal(content, cassette); err != nil {
+		t.Fatal(err)
+	}
+	cassette.Url = "https://gotify.example.com"
+	client = &http.Client{Transport: &vcrTransport{cassette: cassette}}
+
+	for _, name := range []string{"first", "second"} {
+		res, err := client.Post(cassette.Url+"/application", "application/json", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var application fakeApplication
+		err = json.NewDecoder(res.Body).Decode(&application)
+		res.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if application.Name != name {
+			t.Fatalf("expected application %s to be replayed, got %s", name, application.Name)
+		}
+	}
+
+	if _, err := client.Post(cassette.Url+"/application", "application/json", nil); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Fatalf("expected a request replayed already to fail, got %v", err)
+	}
+
+	if _, err := client.Get(cassette.Url + "/application"); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Fatalf("expected unrecorded request to fail, got %v", err)
+	}
+}
+
+// TestVCRReplayReads checks reads replay their last answer once all of
+// them were replayed, as Terraform refreshes data sources a different number
+// of times across its versions.
+func TestVCRReplayReads(t *testing.T) {
+	cassette := &vcrCassette{
+		Url: "https://gotify.example.com",
+		Interactions: []vcrInteraction{
+			{Method: http.MethodGet, Path: "/version", Status: http.StatusOK, ResponseBody: `{"version":"2.4.0"}`},
+			{Method: http.MethodGet, Path: "/version", Status: http.StatusOK, ResponseBody: `{"version":"2.5.0"}`},
+		},
+	}
+	client := &http.Client{Transport: &vcrTransport{cassette: cassette}}
+
+	for _, expected := range []string{"2.4.0", "2.5.0", "2.5.0"} {
+		res, err := client.Get(cassette.Url + "/version")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(string(body), expected) {
+			t.Fatalf("expected version %s to be replayed, got %s", expected, body)
+		}
+	}
+}
+
+// TestVCRCassettes checks the committed cassettes decode and hold no
+// token.
+func TestVCRCassettes(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "fixtures", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(paths) == 0 {
+		t.Fatal("expected cassettes in testdata/fixtures")
+	}
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var cassette vcrCassette
+		if err := json.Unmarshal(content, &cassette); err != nil {
+			t.Fatalf("can't decode cassette %s: %s", path, err)
+		}
+
+		for _, interaction := range cassette.Interactions {
+			if redactTokens(interaction.ResponseBody) != interaction.ResponseBody {
+				t.Fatalf("expected the tokens of %s %s to be redacted in %s", interaction.Method, interaction.Path, path)
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccVersionDataSource(t *testing.T) {
+	testAccVCR(t, "version_data_source")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccProviderConfig() + testAccVersionDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.gotify_version.test", "version"),
+					resource.TestCheckResourceAttrSet("data.gotify_version.test", "build_date"),
+				),
+			},
+		},
+	})
+}
+
+const testAccVersionDataSourceConfig = `
+data "gotify_version" "test" {}
+`
@@ -7,8 +7,10 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/qjoly/terraform-provider-gotify/internal/generate"
 	"github.com/qjoly/terraform-provider-gotify/internal/provider"
 )
 
@@ -32,6 +34,12 @@ var (
 )
 
 func main() {
+	// "terraform-provider-gotify generate" writes the configuration adopting
+	// an existing Gotify instance instead of serving the provider.
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		os.Exit(generate.Run(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -44,6 +52,11 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	// Terraform collects the provider logs written to stderr.
+	if summary := provider.CallSummary(); summary != "" {
+		log.Printf("[INFO] %s", summary)
+	}
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}